@@ -0,0 +1,898 @@
+// Package clientgen generates Go HTTP client for API described with swgen.Generator.
+package clientgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/swaggest/swgen"
+	"github.com/swaggest/swgen/internal/naming"
+)
+
+// Config controls client generation.
+type Config struct {
+	// PackageName is a name of generated package, default "client".
+	PackageName string
+
+	// Importable reports whether Go package can be imported by generated client.
+	//
+	// By default packages with "internal" or "main" path elements and test packages are not imported,
+	// structures are generated instead of their types.
+	Importable func(pkgPath string) bool
+}
+
+// Generate renders Go source of HTTP client for operations registered in Generator.
+//
+// Generator should have ReflectGoTypes enabled to reuse original Go types in client.
+func Generate(g *swgen.Generator, cfg Config) ([]byte, error) {
+	data, err := g.GenDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	var doc swgen.Document
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	return GenerateDocument(doc, cfg)
+}
+
+// GenerateDocument renders Go source of HTTP client for Swagger 2.0 document.
+func GenerateDocument(doc swgen.Document, cfg Config) ([]byte, error) {
+	if cfg.PackageName == "" {
+		cfg.PackageName = "client"
+	}
+
+	if cfg.Importable == nil {
+		cfg.Importable = defaultImportable
+	}
+
+	c := &codegen{
+		cfg:        cfg,
+		doc:        doc,
+		imports:    make(map[string]string),
+		aliases:    make(map[string]string),
+		defTypes:   make(map[string]string),
+		typeNames:  make(map[string]bool),
+		methodName: make(map[string]bool),
+	}
+
+	return c.generate()
+}
+
+// defaultImportable rejects internal, main and test packages.
+func defaultImportable(pkgPath string) bool {
+	for _, elem := range strings.Split(pkgPath, "/") {
+		if elem == "internal" || elem == "main" {
+			return false
+		}
+	}
+
+	return !strings.HasSuffix(pkgPath, "_test")
+}
+
+type codegen struct {
+	cfg Config
+	doc swgen.Document
+
+	imports map[string]string // Alias by import path.
+	aliases map[string]string // Import path by alias.
+
+	defTypes   map[string]string // Go type by definition name.
+	typeNames  map[string]bool   // Names of generated types.
+	methodName map[string]bool   // Names of generated methods.
+
+	types   bytes.Buffer // Generated type declarations.
+	methods bytes.Buffer // Generated client methods.
+}
+
+func (c *codegen) generate() ([]byte, error) {
+	for _, p := range naming.SortedKeys(c.doc.Paths) {
+		ops := c.doc.Paths[p].Map()
+
+		for _, method := range naming.SortedKeys(ops) {
+			if err := c.operation(p, method, ops[method]); err != nil {
+				return nil, fmt.Errorf("failed to generate %s %s: %w", method, p, err)
+			}
+		}
+	}
+
+	out := bytes.NewBuffer(nil)
+
+	out.WriteString("// Code generated by github.com/swaggest/swgen/clientgen. DO NOT EDIT.\n\n")
+	out.WriteString("// Package " + c.cfg.PackageName + " provides HTTP client")
+
+	if c.doc.Info.Title != "" {
+		out.WriteString(" for " + c.doc.Info.Title)
+	}
+
+	out.WriteString(".\npackage " + c.cfg.PackageName + "\n\n")
+
+	c.writeRuntime()
+	c.writeImports(out)
+
+	out.Write(c.types.Bytes())
+	out.Write(c.methods.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return out.Bytes(), fmt.Errorf("failed to format generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+func (c *codegen) writeImports(out *bytes.Buffer) {
+	std := []string{"encoding", "encoding/json", "fmt", "io/ioutil", "net/http", "net/url", "reflect", "strings"}
+
+	if c.methods.Len() > 0 {
+		std = append(std, "context", "io")
+	}
+
+	for _, p := range std {
+		c.importPath(p, path.Base(p))
+	}
+
+	var stdPaths, extPaths []string
+
+	for _, p := range naming.SortedKeys(c.imports) {
+		if strings.Contains(strings.Split(p, "/")[0], ".") {
+			extPaths = append(extPaths, p)
+		} else {
+			stdPaths = append(stdPaths, p)
+		}
+	}
+
+	out.WriteString("import (\n")
+
+	for i, paths := range [][]string{stdPaths, extPaths} {
+		if i > 0 && len(paths) > 0 {
+			out.WriteString("\n")
+		}
+
+		for _, p := range paths {
+			if alias := c.imports[p]; alias != path.Base(p) {
+				out.WriteString("\t" + alias + " " + strconv.Quote(p) + "\n")
+			} else {
+				out.WriteString("\t" + strconv.Quote(p) + "\n")
+			}
+		}
+	}
+
+	out.WriteString(")\n\n")
+}
+
+// importPath registers import and returns its alias.
+func (c *codegen) importPath(pkgPath, pkgName string) string {
+	if alias, ok := c.imports[pkgPath]; ok {
+		return alias
+	}
+
+	alias := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+
+		return -1
+	}, pkgName)
+
+	if alias == "" || unicode.IsDigit(rune(alias[0])) {
+		alias = "pkg" + alias
+	}
+
+	base := alias
+
+	for i := 2; ; i++ {
+		if _, taken := c.aliases[alias]; !taken {
+			break
+		}
+
+		alias = base + strconv.Itoa(i)
+	}
+
+	c.imports[pkgPath] = alias
+	c.aliases[alias] = pkgPath
+
+	return alias
+}
+
+var builtinTypes = map[string]bool{
+	"bool": true, "string": true, "byte": true, "rune": true, "error": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"float32": true, "float64": true, "complex64": true, "complex128": true,
+}
+
+// goType converts "x-go-type" value to Go type expression, false is returned if type can not be referenced.
+func (c *codegen) goType(goType string) (string, bool) {
+	switch {
+	case goType == "":
+		return "", false
+	case strings.HasPrefix(goType, "*"):
+		t, ok := c.goType(goType[1:])
+
+		return "*" + t, ok
+	case strings.HasPrefix(goType, "[]"):
+		t, ok := c.goType(goType[2:])
+
+		return "[]" + t, ok
+	case strings.HasPrefix(goType, "map["):
+		depth := 0
+
+		for i := 3; i < len(goType); i++ {
+			switch goType[i] {
+			case '[':
+				depth++
+			case ']':
+				depth--
+
+				if depth == 0 {
+					k, kok := c.goType(goType[4:i])
+					v, vok := c.goType(goType[i+1:])
+
+					return "map[" + k + "]" + v, kok && vok
+				}
+			}
+		}
+
+		return "", false
+	}
+
+	name, display := goType, ""
+	if pos := strings.Index(goType, "::"); pos != -1 {
+		name, display = goType[:pos], goType[pos+2:]
+	}
+
+	if name == "" {
+		if display == "interface {}" {
+			return "interface{}", true
+		}
+
+		return "", false
+	}
+
+	pos := strings.LastIndex(name, ".")
+	if pos == -1 {
+		return name, builtinTypes[name]
+	}
+
+	pkgPath, typeName := name[:pos], name[pos+1:]
+
+	if typeName == "" || !unicode.IsUpper(rune(typeName[0])) || strings.ContainsAny(typeName, "[]") ||
+		!c.cfg.Importable(pkgPath) {
+		return "", false
+	}
+
+	pkgName := path.Base(pkgPath)
+	if pos := strings.LastIndex(display, "."); pos != -1 {
+		pkgName = strings.TrimLeft(display[:pos], "*[]")
+	}
+
+	return c.importPath(pkgPath, pkgName) + "." + typeName, true
+}
+
+// schemaType returns Go type expression for schema, generating types if necessary.
+func (c *codegen) schemaType(s swgen.SchemaObj) string {
+	if t, ok := c.goType(s.GoType); ok {
+		return t
+	}
+
+	if s.Ref != "" {
+		return c.definitionType(strings.TrimPrefix(s.Ref, "#/definitions/"))
+	}
+
+	switch s.Type {
+	case "array":
+		if s.Items == nil {
+			return "[]interface{}"
+		}
+
+		return "[]" + c.schemaType(*s.Items)
+	case "object":
+		if len(s.Properties) > 0 {
+			return c.structType(s)
+		}
+
+		if s.AdditionalProperties != nil {
+			return "map[string]" + c.schemaType(*s.AdditionalProperties)
+		}
+
+		return "map[string]interface{}"
+	}
+
+	return c.scalarType(s.Type, s.Format)
+}
+
+func (c *codegen) scalarType(typ, format string) string {
+	switch typ {
+	case "string":
+		if format == "date-time" {
+			return c.importPath("time", "time") + ".Time"
+		}
+
+		return "string"
+	case "integer":
+		if format == "int32" {
+			return "int32"
+		}
+
+		return "int64"
+	case "number":
+		if format == "float" {
+			return "float32"
+		}
+
+		return "float64"
+	case "boolean":
+		return "bool"
+	}
+
+	return "interface{}"
+}
+
+// definitionType returns Go type of named definition, original type is used if possible.
+func (c *codegen) definitionType(name string) string {
+	if t, ok := c.defTypes[name]; ok {
+		return t
+	}
+
+	def, found := c.doc.Definitions[name]
+	if !found {
+		return "interface{}"
+	}
+
+	if t, ok := c.goType(strings.TrimLeft(def.GoType, "*")); ok {
+		c.defTypes[name] = t
+
+		return t
+	}
+
+	typeName := c.typeName(naming.Exported(name))
+	c.defTypes[name] = typeName // Registered before generation to support recursive types.
+
+	def.GoType = ""
+
+	var t string
+	if def.Type == "object" && len(def.Properties) > 0 {
+		t = c.structType(def)
+	} else {
+		t = c.schemaType(def)
+	}
+
+	if def.Description == "" {
+		def.Description = fmt.Sprintf("a structure of %q definition.", name)
+	}
+
+	c.writeDoc(&c.types, typeName, def.Description)
+	fmt.Fprintf(&c.types, "type %s %s\n\n", typeName, t)
+
+	return typeName
+}
+
+// typeName reserves unique name for generated type.
+func (c *codegen) typeName(name string) string {
+	unique := name
+
+	for i := 2; c.typeNames[unique] || unique == "Client" || unique == "Error"; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+
+	c.typeNames[unique] = true
+
+	return unique
+}
+
+func (c *codegen) structType(s swgen.SchemaObj) string {
+	required := make(map[string]bool, len(s.Required))
+	for _, r := range s.Required {
+		required[r] = true
+	}
+
+	fieldNames := make(map[string]bool, len(s.Properties))
+	res := bytes.NewBufferString("struct {\n")
+
+	for _, name := range naming.SortedKeys(s.Properties) {
+		prop := s.Properties[name]
+
+		fieldName := s.GoPropertyNames[name]
+		if fieldName == "" {
+			fieldName = naming.Exported(name)
+		}
+
+		for i := 2; fieldNames[fieldName]; i++ {
+			fieldName = naming.Exported(name) + strconv.Itoa(i)
+		}
+
+		fieldNames[fieldName] = true
+
+		fieldType, ok := c.goType(s.GoPropertyTypes[name])
+		if !ok {
+			fieldType = c.schemaType(prop)
+		}
+
+		tag := name
+		if !required[name] {
+			tag += ",omitempty"
+		}
+
+		if prop.Description != "" {
+			res.WriteString("// " + strings.ReplaceAll(strings.TrimSpace(prop.Description), "\n", "\n// ") + "\n")
+		}
+
+		fmt.Fprintf(res, "%s %s `json:%q`\n", fieldName, fieldType, tag)
+	}
+
+	res.WriteString("}")
+
+	return res.String()
+}
+
+func (c *codegen) writeDoc(buf *bytes.Buffer, name, description string) {
+	if description == "" {
+		return
+	}
+
+	lines := strings.Split(strings.TrimSpace(description), "\n")
+	lines[0] = name + " is " + strings.TrimSuffix(strings.ToLower(lines[0][:1])+lines[0][1:], ".") + "."
+
+	for _, l := range lines {
+		buf.WriteString(strings.TrimRight("// "+l, " ") + "\n")
+	}
+}
+
+// paramType returns Go type for non-body parameter.
+func (c *codegen) paramType(p swgen.ParamObj) string {
+	if goType, ok := p.ExtendedField("x-go-type"); ok {
+		if s, ok := goType.(string); ok {
+			if t, ok := c.goType(s); ok {
+				return t
+			}
+		}
+	}
+
+	if p.Type == "array" && p.Items != nil {
+		return "[]" + c.scalarType(p.Items.Type, p.Items.Format)
+	}
+
+	return c.scalarType(p.Type, p.Format)
+}
+
+// operationRequest describes request argument of client method.
+type operationRequest struct {
+	typ    string            // Go type of argument, empty if there is no request.
+	fields map[string]string // Go field expression by parameter name and in, e.g. "req.ID".
+	body   string            // Expression of body value.
+}
+
+func (c *codegen) request(methodName string, op *swgen.OperationObj) (operationRequest, error) {
+	var (
+		req      operationRequest
+		params   []swgen.ParamObj
+		bodyType string
+	)
+
+	req.fields = make(map[string]string)
+
+	for _, p := range op.Parameters {
+		if p.In == "body" {
+			if p.Schema != nil {
+				bodyType = c.schemaType(*p.Schema)
+			}
+
+			req.body = "req"
+
+			continue
+		}
+
+		params = append(params, p)
+	}
+
+	if len(params) == 0 {
+		if req.body != "" {
+			req.typ = bodyType
+			if req.typ == "" {
+				req.typ = "interface{}"
+			}
+		}
+
+		return req, nil
+	}
+
+	// Original request type is reused if all parameters are mapped to its fields.
+	if reqType, ok := op.ExtendedField("x-request-go-type"); ok {
+		if s, ok := reqType.(string); ok {
+			if t, ok := c.goType(strings.TrimPrefix(s, "*")); ok {
+				reused := true
+
+				for _, p := range params {
+					fieldName, ok := p.ExtendedField("x-go-name")
+					if !ok {
+						reused = false
+
+						break
+					}
+
+					req.fields[p.In+" "+p.Name] = fmt.Sprintf("req.%s", fieldName)
+				}
+
+				if reused {
+					req.typ = t
+
+					return req, nil
+				}
+			}
+		}
+	}
+
+	req.typ = c.typeName(methodName + "Request")
+
+	fieldNames := make(map[string]bool, len(params))
+	decl := bytes.NewBufferString("struct {\n")
+
+	for _, p := range params {
+		fieldName := naming.Exported(p.Name)
+
+		if goName, ok := p.ExtendedField("x-go-name"); ok {
+			if s, ok := goName.(string); ok && s != "" {
+				fieldName = s
+			}
+		}
+
+		for i := 2; fieldNames[fieldName] || fieldName == "Body"; i++ {
+			fieldName = naming.Exported(p.Name) + strconv.Itoa(i)
+		}
+
+		fieldNames[fieldName] = true
+		req.fields[p.In+" "+p.Name] = "req." + fieldName
+
+		if p.Description != "" {
+			decl.WriteString("// " + strings.ReplaceAll(strings.TrimSpace(p.Description), "\n", "\n// ") + "\n")
+		}
+
+		fmt.Fprintf(decl, "%s %s // %s parameter %q.\n", fieldName, c.paramType(p), strings.Title(p.In), p.Name)
+	}
+
+	if req.body != "" {
+		if bodyType == "" {
+			bodyType = "interface{}"
+		}
+
+		fmt.Fprintf(decl, "Body %s\n", bodyType)
+
+		req.body = "req.Body"
+	}
+
+	decl.WriteString("}")
+
+	fmt.Fprintf(&c.types, "// %s is a request of %s method.\ntype %s %s\n\n", req.typ, methodName, req.typ, decl.String())
+
+	return req, nil
+}
+
+// successResponse returns status code and schema of successful response.
+func successResponse(op *swgen.OperationObj) (int, *swgen.SchemaObj) {
+	for _, code := range naming.SortedIntKeys(op.Responses) {
+		if code >= 200 && code < 300 {
+			return code, op.Responses[code].Schema
+		}
+	}
+
+	return http.StatusOK, nil
+}
+
+func (c *codegen) operation(p, method string, op *swgen.OperationObj) error {
+	// Multipart uploads are not supported, operation is skipped to keep the rest of client usable.
+	for _, param := range op.Parameters {
+		if param.Type == "file" || (param.Items != nil && param.Items.Type == "file") {
+			fmt.Fprintf(&c.methods, "// %s %s is skipped, file parameter %q is not supported.\n\n", method, p, param.Name)
+
+			return nil
+		}
+	}
+
+	methodName := c.operationName(p, method)
+
+	req, err := c.request(methodName, op)
+	if err != nil {
+		return err
+	}
+
+	statusCode, respSchema := successResponse(op)
+
+	var outType string
+	if respSchema != nil {
+		outType = c.schemaType(*respSchema)
+	}
+
+	outRef := outType
+	if outType != "" && !strings.HasPrefix(outType, "[]") && !strings.HasPrefix(outType, "map[") {
+		outRef = "*" + outType
+	}
+
+	errReturn := "return err"
+	if outType != "" {
+		errReturn = "return nil, err"
+	}
+
+	m := &c.methods
+
+	fmt.Fprintf(m, "// %s performs %s %s.\n", methodName, method, p)
+
+	if op.Summary != "" || op.Description != "" {
+		m.WriteString("//\n")
+	}
+
+	for _, text := range []string{op.Summary, op.Description} {
+		if text = strings.TrimSpace(text); text != "" {
+			if !strings.HasSuffix(text, ".") {
+				text += "." // Avoids rendering of single line as doc heading.
+			}
+
+			m.WriteString("// " + strings.ReplaceAll(text, "\n", "\n// ") + "\n")
+		}
+	}
+
+	if op.Deprecated {
+		m.WriteString("//\n// Deprecated: operation is deprecated.\n")
+	}
+
+	fmt.Fprintf(m, "func (c *Client) %s(ctx context.Context", methodName)
+
+	if req.typ != "" {
+		if strings.HasPrefix(req.typ, "[]") || strings.HasPrefix(req.typ, "map[") || req.typ == "interface{}" {
+			fmt.Fprintf(m, ", req %s", req.typ)
+		} else {
+			fmt.Fprintf(m, ", req *%s", req.typ)
+		}
+	}
+
+	if outType != "" {
+		fmt.Fprintf(m, ") (%s, error) {\n", outRef)
+	} else {
+		m.WriteString(") error {\n")
+	}
+
+	basePath := strings.TrimRight(c.doc.BasePath, "/")
+	fmt.Fprintf(m, "uri := %q\n", basePath+p)
+
+	var (
+		query, form, header, cookie []swgen.ParamObj
+	)
+
+	for _, param := range op.Parameters {
+		switch param.In {
+		case "path":
+			fmt.Fprintf(m, "uri = strings.Replace(uri, %q, pathValue(%s, %q), 1)\n",
+				"{"+param.Name+"}", req.fields[param.In+" "+param.Name], param.CollectionFormat)
+		case "query":
+			query = append(query, param)
+		case "formData":
+			form = append(form, param)
+		case "header":
+			header = append(header, param)
+		case "cookie":
+			cookie = append(cookie, param)
+		}
+	}
+
+	writeValues := func(name string, params []swgen.ParamObj) {
+		fmt.Fprintf(m, "\n%s := url.Values{}\n", name)
+
+		for _, param := range params {
+			fmt.Fprintf(m, "if v, ok := paramValues(%s, %q, %t); ok {\n%s[%q] = v\n}\n",
+				req.fields[param.In+" "+param.Name], param.CollectionFormat, param.Required, name, param.Name)
+		}
+	}
+
+	if len(query) > 0 {
+		writeValues("query", query)
+		m.WriteString("\nif len(query) > 0 {\nuri += \"?\" + query.Encode()\n}\n")
+	}
+
+	m.WriteString("\nvar body io.Reader\n")
+
+	contentType := ""
+
+	switch {
+	case len(form) > 0:
+		writeValues("form", form)
+		m.WriteString("\nbody = strings.NewReader(form.Encode())\n")
+
+		contentType = "application/x-www-form-urlencoded"
+	case req.body != "":
+		c.importPath("bytes", "bytes")
+		fmt.Fprintf(m, "\nb, err := json.Marshal(%s)\nif err != nil {\n%s\n}\n\nbody = bytes.NewReader(b)\n",
+			req.body, errReturn)
+
+		contentType = "application/json"
+	}
+
+	fmt.Fprintf(m, "\nhttpReq, err := http.NewRequestWithContext(ctx, %q, c.BaseURL+uri, body)\n", method)
+	fmt.Fprintf(m, "if err != nil {\n%s\n}\n", errReturn)
+
+	if contentType != "" {
+		fmt.Fprintf(m, "\nhttpReq.Header.Set(\"Content-Type\", %q)\n", contentType)
+	}
+
+	for _, param := range header {
+		fmt.Fprintf(m, "\nif v, ok := paramValues(%s, %q, %t); ok {\nhttpReq.Header.Set(%q, v[0])\n}\n",
+			req.fields[param.In+" "+param.Name], param.CollectionFormat, param.Required, param.Name)
+	}
+
+	for _, param := range cookie {
+		fmt.Fprintf(m, "\nif v, ok := paramValues(%s, %q, %t); ok {\nhttpReq.AddCookie(&http.Cookie{Name: %q, Value: v[0]})\n}\n",
+			req.fields[param.In+" "+param.Name], param.CollectionFormat, param.Required, param.Name)
+	}
+
+	if outType == "" {
+		fmt.Fprintf(m, "\nreturn c.do(httpReq, %d, nil)\n}\n\n", statusCode)
+
+		return nil
+	}
+
+	fmt.Fprintf(m, "\nvar out %s\n\nif err := c.do(httpReq, %d, &out); err != nil {\nreturn nil, err\n}\n\n",
+		outType, statusCode)
+
+	if outRef == outType {
+		m.WriteString("return out, nil\n}\n\n")
+	} else {
+		m.WriteString("return &out, nil\n}\n\n")
+	}
+
+	return nil
+}
+
+// operationName makes unique method name from HTTP method and path, e.g. "GetPetsByID" for "GET /pets/{id}".
+func (c *codegen) operationName(p, method string) string {
+	name := naming.Exported(strings.ToLower(method))
+
+	for _, segment := range strings.Split(p, "/") {
+		if segment == "" {
+			continue
+		}
+
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			name += "By" + naming.Exported(strings.Trim(segment, "{}"))
+		} else {
+			name += naming.Exported(segment)
+		}
+	}
+
+	unique := name
+
+	for i := 2; c.methodName[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+
+	c.methodName[unique] = true
+
+	return unique
+}
+
+func (c *codegen) writeRuntime() {
+	title := "API"
+	if c.doc.Info.Title != "" {
+		title = c.doc.Info.Title
+	}
+
+	fmt.Fprintf(&c.types, `// Client is an HTTP client of %s.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates Client with base URL, e.g. "https://api.example.com".
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// Error is returned for response with unexpected status code.
+type Error struct {
+	StatusCode int
+	Body       []byte
+}
+
+// Error implements error.
+func (e Error) Error() string {
+	return fmt.Sprintf("unexpected response status %%d: %%s", e.StatusCode, e.Body)
+}
+
+func (c *Client) do(req *http.Request, statusCode int, out interface{}) error {
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != statusCode {
+		return Error{StatusCode: resp.StatusCode, Body: body}
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.Unmarshal(body, out)
+}
+
+// paramValues formats parameter value, false is returned for empty optional value.
+func paramValues(value interface{}, collectionFormat string, required bool) ([]string, bool) {
+	v := reflect.ValueOf(value)
+
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if !required && (!v.IsValid() || v.IsZero()) {
+		return nil, false
+	}
+
+	var values []string
+
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, formatValue(v.Index(i)))
+		}
+	} else {
+		values = append(values, formatValue(v))
+	}
+
+	if collectionFormat == "multi" {
+		return values, true
+	}
+
+	sep := ","
+
+	switch collectionFormat {
+	case "ssv":
+		sep = " "
+	case "tsv":
+		sep = "\t"
+	case "pipes":
+		sep = "|"
+	}
+
+	return []string{strings.Join(values, sep)}, true
+}
+
+// pathValue formats escaped path parameter value.
+func pathValue(value interface{}, collectionFormat string) string {
+	values, _ := paramValues(value, collectionFormat, true)
+
+	return url.PathEscape(values[0])
+}
+
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return ""
+	}
+
+	if tm, ok := v.Interface().(encoding.TextMarshaler); ok {
+		if b, err := tm.MarshalText(); err == nil {
+			return string(b)
+		}
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+`, title)
+}
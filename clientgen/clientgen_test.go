@@ -0,0 +1,140 @@
+package clientgen_test
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/swgen"
+	"github.com/swaggest/swgen/clientgen"
+	"github.com/swaggest/swgen/clientgen/internal/petapi"
+	"github.com/swaggest/swgen/clientgen/internal/petclient"
+)
+
+// update regenerates golden file instead of comparing with it, e.g. "go test . -update".
+var update = flag.Bool("update", false, "update golden file")
+
+func generatePetClient() ([]byte, error) {
+	return clientgen.Generate(petapi.NewGenerator(), clientgen.Config{
+		PackageName: "petclient",
+		Importable: func(pkgPath string) bool {
+			return pkgPath == "github.com/swaggest/swgen/clientgen/internal/petapi"
+		},
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := generatePetClient()
+	require.NoError(t, err)
+
+	expected, err := ioutil.ReadFile("internal/petclient/client.go")
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, ioutil.WriteFile("internal/petclient/client.go", src, 0o600))
+
+		return
+	}
+
+	assert.Equal(t, string(expected), string(src), "run tests with -update to regenerate internal/petclient/client.go")
+}
+
+func TestGenerate_defaultImportable(t *testing.T) {
+	src, err := clientgen.Generate(petapi.NewGenerator(), clientgen.Config{})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(src), "package client\n")
+	assert.NotContains(t, string(src), "petapi")
+	assert.Contains(t, string(src), "type Pet struct {")
+	assert.Contains(t, string(src), "func (c *Client) GetPets(ctx context.Context, req *GetPetsRequest) ([]Pet, error) {")
+}
+
+func TestGenerate_fileParam(t *testing.T) {
+	type uploadRequest struct {
+		Photos []*multipart.FileHeader `formData:"photos"`
+	}
+
+	g := petapi.NewGenerator()
+	g.SetPathItem(swgen.PathItemInfo{Path: "/api/photos", Method: http.MethodPost, Request: new(uploadRequest)})
+
+	src, err := clientgen.Generate(g, clientgen.Config{})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(src), "// POST /api/photos is skipped, file parameter \"photos\" is not supported.\n")
+	assert.NotContains(t, string(src), "PostPhotos")
+	assert.Contains(t, string(src), "func (c *Client) GetPets(ctx context.Context, req *GetPetsRequest) ([]Pet, error) {")
+}
+
+func TestGenerate_client(t *testing.T) {
+	g := petapi.NewGenerator()
+	pets := map[string]petapi.Pet{"1": {ID: 1, Name: "Tom", Tags: []string{"cat"}}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/pets", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var p petapi.Pet
+
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+
+			p.ID = 2
+			assert.NoError(t, json.NewEncoder(w).Encode(p))
+
+			return
+		}
+
+		assert.Equal(t, "cat,dog", r.URL.Query().Get("tags"))
+		assert.Equal(t, "", r.URL.Query().Get("limit"))
+		assert.NoError(t, json.NewEncoder(w).Encode([]petapi.Pet{pets["1"]}))
+	})
+	mux.HandleFunc("/api/pets/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/api/pets/")
+
+		p, found := pets[id]
+		if !found {
+			http.Error(w, "not found", http.StatusNotFound)
+
+			return
+		}
+
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		assert.Equal(t, "abc", r.Header.Get("X-Trace-ID"))
+		assert.NoError(t, json.NewEncoder(w).Encode(p))
+	})
+
+	srv := httptest.NewServer(swgen.ValidateRequests(g, mux))
+	defer srv.Close()
+
+	c := petclient.NewClient(srv.URL)
+	ctx := context.Background()
+
+	found, err := c.GetPets(ctx, &petapi.FindPetsRequest{Tags: []string{"cat", "dog"}})
+	require.NoError(t, err)
+	assert.Equal(t, []petapi.Pet{pets["1"]}, found)
+
+	added, err := c.PostPets(ctx, &petclient.NewPet{Name: "Jerry"})
+	require.NoError(t, err)
+	assert.Equal(t, &petapi.Pet{ID: 2, Name: "Jerry"}, added)
+
+	pet, err := c.GetPetsByID(ctx, &petapi.GetPetRequest{ID: 1, TraceID: "abc"})
+	require.NoError(t, err)
+	assert.Equal(t, "Tom", pet.Name)
+
+	_, err = c.GetPetsByID(ctx, &petapi.GetPetRequest{ID: 3, TraceID: "abc"})
+	assert.EqualError(t, err, "unexpected response status 404: not found\n")
+	assert.Equal(t, http.StatusNotFound, err.(petclient.Error).StatusCode)
+
+	require.NoError(t, c.DeletePetsByID(ctx, &petclient.DeletePetsByIDRequest{ID: 1}))
+}
@@ -0,0 +1,84 @@
+// Package petapi describes sample API to test client generation.
+package petapi
+
+import (
+	"net/http"
+
+	"github.com/swaggest/swgen"
+)
+
+// Pet is a pet in store.
+type Pet struct {
+	ID     int64    `json:"id"`
+	Name   string   `json:"name"`
+	Tags   []string `json:"tags,omitempty"`
+	Status string   `json:"status,omitempty"`
+}
+
+// FindPetsRequest filters pets.
+type FindPetsRequest struct {
+	Tags  []string `query:"tags" collectionFormat:"csv"`
+	Limit int      `query:"limit"`
+}
+
+// GetPetRequest identifies pet.
+type GetPetRequest struct {
+	ID      int64  `path:"id"`
+	TraceID string `header:"X-Trace-ID"`
+}
+
+// newPet is not exported, so client generates own structure.
+type newPet struct {
+	Name string   `json:"name" required:"true"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+// Error describes failure.
+type Error struct {
+	Message string `json:"message"`
+}
+
+// NewGenerator creates generator with sample API.
+func NewGenerator() *swgen.Generator {
+	g := swgen.NewGenerator().ReflectGoTypes(true)
+	g.SetInfo("Pets", "Sample pet store.", "", "1.0.0")
+	g.SetBasePath("/api")
+
+	g.SetPathItem(swgen.PathItemInfo{
+		Path:        "/pets",
+		Method:      http.MethodGet,
+		Title:       "Find pets",
+		Description: "Lists pets filtered by tags.",
+		Request:     new(FindPetsRequest),
+		Response:    []Pet{},
+	})
+
+	g.SetPathItem(swgen.PathItemInfo{
+		Path:     "/pets",
+		Method:   http.MethodPost,
+		Title:    "Add pet",
+		Request:  new(newPet),
+		Response: new(Pet),
+	}).AddExtendedField("x-sample", true)
+
+	g.SetPathItem(swgen.PathItemInfo{
+		Path:     "/pets/{id}",
+		Method:   http.MethodGet,
+		Title:    "Get pet",
+		Request:  new(GetPetRequest),
+		Response: new(Pet),
+	})
+
+	g.SetPathItem(swgen.PathItemInfo{
+		Path:       "/pets/{id}",
+		Method:     http.MethodDelete,
+		Title:      "Delete pet",
+		Deprecated: true,
+		Request: new(struct {
+			ID int64 `path:"id"`
+		}),
+		SuccessfulResponseCode: http.StatusNoContent,
+	})
+
+	return g
+}
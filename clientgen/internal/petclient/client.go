@@ -0,0 +1,253 @@
+// Code generated by github.com/swaggest/swgen/clientgen. DO NOT EDIT.
+
+// Package petclient provides HTTP client for Pets.
+package petclient
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/swaggest/swgen/clientgen/internal/petapi"
+)
+
+// NewPet is a structure of "newPet" definition.
+type NewPet struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+// DeletePetsByIDRequest is a request of DeletePetsByID method.
+type DeletePetsByIDRequest struct {
+	ID int64 // Path parameter "id".
+}
+
+// Client is an HTTP client of Pets.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient creates Client with base URL, e.g. "https://api.example.com".
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+// Error is returned for response with unexpected status code.
+type Error struct {
+	StatusCode int
+	Body       []byte
+}
+
+// Error implements error.
+func (e Error) Error() string {
+	return fmt.Sprintf("unexpected response status %d: %s", e.StatusCode, e.Body)
+}
+
+func (c *Client) do(req *http.Request, statusCode int, out interface{}) error {
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != statusCode {
+		return Error{StatusCode: resp.StatusCode, Body: body}
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.Unmarshal(body, out)
+}
+
+// paramValues formats parameter value, false is returned for empty optional value.
+func paramValues(value interface{}, collectionFormat string, required bool) ([]string, bool) {
+	v := reflect.ValueOf(value)
+
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if !required && (!v.IsValid() || v.IsZero()) {
+		return nil, false
+	}
+
+	var values []string
+
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, formatValue(v.Index(i)))
+		}
+	} else {
+		values = append(values, formatValue(v))
+	}
+
+	if collectionFormat == "multi" {
+		return values, true
+	}
+
+	sep := ","
+
+	switch collectionFormat {
+	case "ssv":
+		sep = " "
+	case "tsv":
+		sep = "\t"
+	case "pipes":
+		sep = "|"
+	}
+
+	return []string{strings.Join(values, sep)}, true
+}
+
+// pathValue formats escaped path parameter value.
+func pathValue(value interface{}, collectionFormat string) string {
+	values, _ := paramValues(value, collectionFormat, true)
+
+	return url.PathEscape(values[0])
+}
+
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return ""
+	}
+
+	if tm, ok := v.Interface().(encoding.TextMarshaler); ok {
+		if b, err := tm.MarshalText(); err == nil {
+			return string(b)
+		}
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+// GetPets performs GET /pets.
+//
+// Find pets.
+// Lists pets filtered by tags.
+func (c *Client) GetPets(ctx context.Context, req *petapi.FindPetsRequest) ([]petapi.Pet, error) {
+	uri := "/api/pets"
+
+	query := url.Values{}
+	if v, ok := paramValues(req.Tags, "csv", false); ok {
+		query["tags"] = v
+	}
+	if v, ok := paramValues(req.Limit, "", false); ok {
+		query["limit"] = v
+	}
+
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+
+	var body io.Reader
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+uri, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []petapi.Pet
+
+	if err := c.do(httpReq, 200, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// PostPets performs POST /pets.
+//
+// Add pet.
+func (c *Client) PostPets(ctx context.Context, req *NewPet) (*petapi.Pet, error) {
+	uri := "/api/pets"
+
+	var body io.Reader
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body = bytes.NewReader(b)
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+uri, body)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	var out petapi.Pet
+
+	if err := c.do(httpReq, 200, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// DeletePetsByID performs DELETE /pets/{id}.
+//
+// Delete pet.
+//
+// Deprecated: operation is deprecated.
+func (c *Client) DeletePetsByID(ctx context.Context, req *DeletePetsByIDRequest) error {
+	uri := "/api/pets/{id}"
+	uri = strings.Replace(uri, "{id}", pathValue(req.ID, ""), 1)
+
+	var body io.Reader
+
+	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", c.BaseURL+uri, body)
+	if err != nil {
+		return err
+	}
+
+	return c.do(httpReq, 204, nil)
+}
+
+// GetPetsByID performs GET /pets/{id}.
+//
+// Get pet.
+func (c *Client) GetPetsByID(ctx context.Context, req *petapi.GetPetRequest) (*petapi.Pet, error) {
+	uri := "/api/pets/{id}"
+	uri = strings.Replace(uri, "{id}", pathValue(req.ID, ""), 1)
+
+	var body io.Reader
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+uri, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := paramValues(req.TraceID, "", false); ok {
+		httpReq.Header.Set("X-Trace-ID", v[0])
+	}
+
+	var out petapi.Pet
+
+	if err := c.do(httpReq, 200, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
@@ -0,0 +1,162 @@
+package swgen
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// xCollectionFormat keeps Swagger 2.0 collectionFormat that has no OpenAPI 3.0 style, e.g. "tsv".
+const xCollectionFormat = "x-collectionFormat"
+
+// SetDefaultCollectionFormat sets collectionFormat of array parameters that have no `collectionFormat` tag.
+//
+// Default is "multi", it is replaced with "csv" in path and header, as "multi" is only valid in query and formData.
+// Format is resolved when path item is registered, so it should be set before SetPathItem.
+func (g *Generator) SetDefaultCollectionFormat(format string) *Generator {
+	g.mu.Lock()
+	g.collectionFormat = format
+	g.ResetDocumentCache()
+	g.mu.Unlock()
+
+	return g
+}
+
+// SetCollectionFormatIn sets collectionFormat of array parameters located in "in" (e.g. "header")
+// that have no `collectionFormat` tag, it overrides SetDefaultCollectionFormat.
+// Format is resolved when path item is registered, so it should be set before SetPathItem.
+func (g *Generator) SetCollectionFormatIn(in, format string) *Generator {
+	g.mu.Lock()
+
+	if g.collectionFormatsIn == nil {
+		g.collectionFormatsIn = make(map[string]string)
+	}
+
+	g.collectionFormatsIn[in] = format
+	g.ResetDocumentCache()
+	g.mu.Unlock()
+
+	return g
+}
+
+// configuredCollectionFormat returns configured collectionFormat for parameters in location, empty if not configured.
+func (g *Generator) configuredCollectionFormat(in string) string {
+	format, ok := g.collectionFormatsIn[in]
+	if !ok {
+		format = g.collectionFormat
+	}
+
+	return collectionFormatIn(in, format)
+}
+
+// defaultCollectionFormat returns collectionFormat for array parameters in location that have no tag.
+func (g *Generator) defaultCollectionFormat(in string) string {
+	if format := g.configuredCollectionFormat(in); format != "" {
+		return format
+	}
+
+	return collectionFormatIn(in, "multi")
+}
+
+// collectionFormatIn replaces "multi" with "csv" in locations other than query and formData.
+func collectionFormatIn(in, format string) string {
+	if format == "multi" && in != "query" && in != "formData" {
+		return "csv"
+	}
+
+	return format
+}
+
+// oas3FormEncoding adds serialization of array fields to encoding of "application/x-www-form-urlencoded" body.
+func oas3FormEncoding(encoding map[string]OAS3EncodingObj, params []ParamObj) map[string]OAS3EncodingObj {
+	for _, p := range params {
+		if p.In != "formData" || p.Type != "array" || isFileParam(p) {
+			continue
+		}
+
+		style, explode := oas3Style("query", p.CollectionFormat)
+		if style == "" {
+			continue
+		}
+
+		if encoding == nil {
+			encoding = make(map[string]OAS3EncodingObj)
+		}
+
+		e := encoding[p.Name]
+		e.Style, e.Explode = style, explode
+		encoding[p.Name] = e
+	}
+
+	return encoding
+}
+
+// setOpenAPICollectionFormats sets style of array parameters without explicit style in OpenAPI 3.0 operation,
+// `collectionFormat` tags of request fields take precedence over collectionFormat that returns configured format
+// for location or empty string.
+func setOpenAPICollectionFormats(op *openapi3.Operation, request interface{}, collectionFormat func(in string) string) {
+	tags := tagCollectionFormats(reflect.TypeOf(request))
+
+	for _, po := range op.Parameters {
+		p := po.Parameter
+		if p == nil || p.Style != nil || p.Schema == nil || p.Schema.Schema == nil ||
+			p.Schema.Schema.Type == nil || *p.Schema.Schema.Type != openapi3.SchemaTypeArray {
+			continue
+		}
+
+		format, ok := tags[string(p.In)+" "+p.Name]
+		if !ok {
+			format = collectionFormat(string(p.In))
+		}
+
+		if format == "" {
+			continue
+		}
+
+		if style, explode := oas3Style(string(p.In), format); style != "" {
+			p.WithStyle(style)
+			p.Explode = explode
+		}
+	}
+}
+
+// tagCollectionFormats returns collection formats of `collectionFormat` tags by location and name of parameter,
+// e.g. "query ids", formats of nested arrays are omitted.
+func tagCollectionFormats(t reflect.Type) map[string]string {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	res := map[string]string{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous {
+			for key, format := range tagCollectionFormats(field.Type) {
+				res[key] = format
+			}
+
+			continue
+		}
+
+		format, ok := field.Tag.Lookup("collectionFormat")
+		if !ok {
+			continue
+		}
+
+		for _, in := range []string{"path", "query", "header", "cookie", "formData"} {
+			name := strings.Split(field.Tag.Get(in), ",")[0]
+			if name != "" && name != "-" {
+				res[in+" "+name] = strings.Split(format, ",")[0]
+			}
+		}
+	}
+
+	return res
+}
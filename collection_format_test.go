@@ -0,0 +1,189 @@
+package swgen_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/swgen"
+)
+
+type searchRequest struct {
+	IDs    []int    `query:"ids"`
+	Tags   []string `query:"tags" collectionFormat:"ssv"`
+	Trace  []string `header:"X-Trace"`
+	Matrix [][]int  `query:"matrix"`
+}
+
+type gridRequest struct {
+	Grid [][]int `query:"grid" collectionFormat:"pipes,csv"`
+}
+
+type tagsForm struct {
+	Tags []string `formData:"tags"`
+}
+
+func TestGenerator_SetDefaultCollectionFormat(t *testing.T) {
+	reflector := openapi3.Reflector{}
+
+	g := swgen.NewGenerator()
+	g.SetOAS3Proxy(&reflector)
+	g.SetDefaultCollectionFormat("csv").SetCollectionFormatIn("header", "pipes")
+
+	g.SetPathItem(swgen.PathItemInfo{Path: "/search", Method: http.MethodGet, Request: new(searchRequest)})
+	g.SetPathItem(swgen.PathItemInfo{Path: "/grid", Method: http.MethodGet, Request: new(gridRequest)})
+	g.SetPathItem(swgen.PathItemInfo{Path: "/tags", Method: http.MethodPost, Request: new(tagsForm),
+		Produces: []string{"application/json"}, Consumes: []string{"application/x-www-form-urlencoded"}})
+
+	data, err := g.GenDocument()
+	require.NoError(t, err)
+
+	var doc swgen.Document
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assertjson.EqualMarshal(t, []byte(`[
+	  {"type":"array","items":{"type":"integer","format":"int32"},"name":"ids","in":"query","collectionFormat":"csv"},
+	  {"type":"array","items":{"type":"string"},"name":"tags","in":"query","collectionFormat":"ssv"},
+	  {"type":"array","items":{"type":"string"},"name":"X-Trace","in":"header","collectionFormat":"pipes"},
+	  {
+	    "type":"array","items":{"type":"array","items":{"type":"integer","format":"int32"},"collectionFormat":"csv"},
+	    "name":"matrix","in":"query","collectionFormat":"csv"
+	  }
+	]`), doc.Paths["/search"].Get.Parameters)
+
+	oas3 := g.DocumentOAS3()
+	assertjson.EqualMarshal(t, []byte(`[
+	  {
+	    "name":"ids","in":"query","schema":{"type":"array","items":{"type":"integer","format":"int32"}},
+	    "style":"form","explode":false
+	  },
+	  {
+	    "name":"tags","in":"query","schema":{"type":"array","items":{"type":"string"}},
+	    "style":"spaceDelimited","explode":false
+	  },
+	  {
+	    "name":"X-Trace","in":"header","schema":{"type":"array","items":{"type":"string"}},
+	    "x-collectionFormat":"pipes"
+	  },
+	  {
+	    "name":"matrix","in":"query",
+	    "schema":{"type":"array","items":{"type":"array","items":{"type":"integer","format":"int32"}}},
+	    "style":"form","explode":false
+	  }
+	]`), oas3.Paths["/search"].Get.Parameters)
+
+	assertjson.EqualMarshal(t, []byte(`{"tags":{"style":"form","explode":false}}`),
+		oas3.Paths["/tags"].Post.RequestBody.Content["application/x-www-form-urlencoded"].Encoding)
+
+	proxied := reflector.Spec.Paths.MapOfPathItemValues["/search"].MapOfOperationValues["get"]
+	assertjson.EqualMarshal(t, []byte(`{
+	  "name":"ids","in":"query","style":"form","explode":false,
+	  "schema":{"type":"array","items":{"type":"integer"}}
+	}`), proxied.Parameters[0].Parameter)
+	assertjson.EqualMarshal(t, []byte(`{
+	  "name":"tags","in":"query","style":"spaceDelimited","explode":false,
+	  "schema":{"type":"array","items":{"type":"string"}}
+	}`), proxied.Parameters[1].Parameter)
+
+	proxied = reflector.Spec.Paths.MapOfPathItemValues["/grid"].MapOfOperationValues["get"]
+	assertjson.EqualMarshal(t, []byte(`{
+	  "name":"grid","in":"query","style":"pipeDelimited","explode":false,
+	  "schema":{"type":"array","items":{"type":"array","items":{"type":"integer"}}}
+	}`), proxied.Parameters[0].Parameter)
+	assert.Equal(t, "pipeDelimited", oas3.Paths["/grid"].Get.Parameters[0].Style)
+
+	v := swgen.NewRequestValidator(g)
+	assert.NoError(t, v.ValidateRequest(httptest.NewRequest(http.MethodGet, "/search?ids=1,2,3&matrix=1,2", nil)))
+
+	err = v.ValidateRequest(httptest.NewRequest(http.MethodGet, "/search?ids=1,x", nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid integer value "x"`)
+}
+
+func TestGenerator_SetDefaultCollectionFormat_multi(t *testing.T) {
+	g := swgen.NewGenerator()
+	g.SetDefaultCollectionFormat("multi")
+
+	g.SetPathItem(swgen.PathItemInfo{Path: "/search", Method: http.MethodGet, Request: new(searchRequest)})
+
+	oas3 := g.DocumentOAS3()
+
+	params := oas3.Paths["/search"].Get.Parameters
+	require.Len(t, params, 4)
+	assert.Equal(t, "form", params[0].Style)
+	assert.True(t, *params[0].Explode)
+
+	// "multi" is only valid in query and formData.
+	assert.Equal(t, "simple", params[2].Style)
+	assert.False(t, *params[2].Explode)
+}
+
+func TestGenerator_defaultCollectionFormat(t *testing.T) {
+	g := swgen.NewGenerator()
+
+	g.SetPathItem(swgen.PathItemInfo{Path: "/search", Method: http.MethodGet, Request: new(searchRequest)})
+
+	params := g.DocumentOAS3().Paths["/search"].Get.Parameters
+	require.Len(t, params, 4)
+	assert.Equal(t, "form", params[0].Style)
+	assert.True(t, *params[0].Explode)
+
+	// Default "multi" falls back to "csv" in header.
+	assert.Equal(t, "simple", params[2].Style)
+	assert.False(t, *params[2].Explode)
+
+	doc, err := g.GenDocument()
+	require.NoError(t, err)
+
+	var d struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Name             string `json:"name"`
+				CollectionFormat string `json:"collectionFormat"`
+			} `json:"parameters"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(doc, &d))
+
+	formats := map[string]string{}
+	for _, p := range d.Paths["/search"]["get"].Parameters {
+		formats[p.Name] = p.CollectionFormat
+	}
+
+	assert.Equal(t, "multi", formats["ids"])
+	assert.Equal(t, "csv", formats["X-Trace"])
+}
+
+func TestGenerator_SetDefaultCollectionFormat_afterRegistration(t *testing.T) {
+	g := swgen.NewGenerator()
+	g.SetPathItem(swgen.PathItemInfo{Path: "/tickets", Method: http.MethodGet, Request: new(ticketsRequest)})
+	g.SetDefaultCollectionFormat("csv")
+	g.SetPathItem(swgen.PathItemInfo{Path: "/search", Method: http.MethodGet, Request: new(ticketsRequest)})
+
+	data, err := g.GenDocument()
+	require.NoError(t, err)
+
+	var doc swgen.Document
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	formats := func(path string) map[string]string {
+		res := map[string]string{}
+		for _, p := range doc.Paths[path].Get.Parameters {
+			res[p.Name] = p.CollectionFormat
+		}
+
+		return res
+	}
+
+	// Format is resolved at registration, deepObject parameters are flattened with the same format.
+	assert.Equal(t, "multi", formats("/tickets")["filter.tags"])
+	assert.Equal(t, "multi", formats("/tickets")["any.name"])
+	assert.Equal(t, "csv", formats("/search")["filter.tags"])
+	assert.Equal(t, "csv", formats("/search")["any.name"])
+}
@@ -0,0 +1,197 @@
+package swgen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/refl"
+)
+
+// ReflectComments enables descriptions from Go doc comments of types and fields declared in package directories.
+//
+// Type comments become descriptions of definitions, field comments become descriptions of properties and
+// parameters, comment of request type becomes operation description if PathItemInfo.Description is empty.
+// Explicit tags and descriptions take precedence. It should be called before registering paths and definitions,
+// subdirectories are not parsed.
+func (g *Generator) ReflectComments(dirs ...string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.ResetDocumentCache()
+
+	if g.comments == nil {
+		g.comments = make(map[string]string)
+	}
+
+	for _, dir := range dirs {
+		if err := g.parseComments(dir); err != nil {
+			return fmt.Errorf("failed to parse comments in %s: %w", dir, err)
+		}
+	}
+
+	return nil
+}
+
+func (g *Generator) parseComments(dir string) error {
+	fset := token.NewFileSet()
+
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	importPath, err := dirImportPath(dir)
+	if err != nil {
+		return err
+	}
+
+	for name, pkg := range pkgs {
+		// Package name is used as a less precise key if directory is not in a module.
+		prefix := importPath
+		if prefix == "" {
+			prefix = name
+		}
+
+		for _, f := range pkg.Files {
+			for _, decl := range f.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.TYPE {
+					continue
+				}
+
+				for _, spec := range gd.Specs {
+					ts := spec.(*ast.TypeSpec)
+
+					doc := ts.Doc
+					if doc == nil && len(gd.Specs) == 1 {
+						doc = gd.Doc
+					}
+
+					g.addComment(prefix+"."+ts.Name.Name, doc)
+
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+
+					for _, field := range st.Fields.List {
+						doc := field.Doc
+						if doc == nil {
+							doc = field.Comment
+						}
+
+						for _, fieldName := range field.Names {
+							g.addComment(prefix+"."+ts.Name.Name+"."+fieldName.Name, doc)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (g *Generator) addComment(key string, doc *ast.CommentGroup) {
+	if doc == nil {
+		return
+	}
+
+	if text := strings.TrimSpace(doc.Text()); text != "" {
+		g.comments[key] = text
+	}
+}
+
+// comment returns doc comment of named type or its field, empty field name stands for type comment.
+func (g *Generator) comment(t reflect.Type, field string) string {
+	if len(g.comments) == 0 || t == nil {
+		return ""
+	}
+
+	t = refl.DeepIndirect(t)
+	if t.Name() == "" {
+		return ""
+	}
+
+	key := t.Name()
+	if field != "" {
+		key += "." + field
+	}
+
+	if c, ok := g.comments[t.PkgPath()+"."+key]; ok {
+		return c
+	}
+
+	// Type string is prefixed with package name, e.g. "pets.Pet".
+	if pos := strings.Index(t.String(), "."); pos != -1 {
+		return g.comments[t.String()[:pos]+"."+key]
+	}
+
+	return ""
+}
+
+// dirImportPath resolves import path of directory with nearest go.mod, empty result means no module found.
+func dirImportPath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for d := abs; ; d = filepath.Dir(d) {
+		data, err := ioutil.ReadFile(filepath.Join(d, "go.mod"))
+		if err == nil {
+			modPath := modulePath(data)
+			if modPath == "" {
+				return "", nil
+			}
+
+			rel, err := filepath.Rel(d, abs)
+			if err != nil {
+				return "", err
+			}
+
+			if rel == "." {
+				return modPath, nil
+			}
+
+			return modPath + "/" + filepath.ToSlash(rel), nil
+		}
+
+		if filepath.Dir(d) == d {
+			return "", nil
+		}
+	}
+}
+
+// modulePath reads module path from go.mod contents.
+func modulePath(mod []byte) string {
+	s := bufio.NewScanner(bytes.NewReader(mod))
+
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 2 || fields[0] != "module" {
+			continue
+		}
+
+		modPath := fields[1]
+		if unquoted, err := strconv.Unquote(modPath); err == nil {
+			modPath = unquoted
+		}
+
+		return modPath
+	}
+
+	return ""
+}
@@ -0,0 +1,75 @@
+package swgen_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/swgen"
+	"github.com/swaggest/swgen/internal/commented"
+)
+
+func TestGenerator_ReflectComments(t *testing.T) {
+	g := swgen.NewGenerator()
+	require.NoError(t, g.ReflectComments("internal/commented"))
+
+	g.SetPathItem(swgen.PathItemInfo{
+		Path:     "/pets",
+		Method:   http.MethodGet,
+		Request:  new(commented.FindPets),
+		Response: []commented.Pet{},
+	})
+	g.SetPathItem(swgen.PathItemInfo{
+		Path:        "/pets/{id}",
+		Method:      http.MethodGet,
+		Description: "Explicit description.",
+		Request:     new(commented.FindPets),
+		Response:    new(commented.Pet),
+	})
+
+	data, err := g.GenDocument()
+	require.NoError(t, err)
+
+	assertjson.Equal(t, []byte(`{
+	  "swagger":"2.0","info":"<ignore-diff>",
+	  "basePath":"/","schemes":["http","https"],
+	  "paths":{
+	    "/pets":{
+	      "get":{
+	        "summary":"","description":"FindPets searches pets by name.\n\nResults are paginated.",
+	        "parameters":[
+	          {"type":"string","description":"Name is a search query.","name":"name","in":"query"},
+	          {"type":"integer","format":"int32","description":"Page size.","name":"limit","in":"query"}
+	        ],
+	        "responses":{"200":{"description":"OK","schema":{"type":"array","items":{"$ref":"#/definitions/Pet"}}}}
+	      }
+	    },
+	    "/pets/{id}":{
+	      "get":{
+	        "summary":"","description":"Explicit description.",
+	        "parameters":"<ignore-diff>",
+	        "responses":{"200":{"description":"OK","schema":{"$ref":"#/definitions/Pet"}}}
+	      }
+	    }
+	  },
+	  "definitions":{
+	    "Owner":{
+	      "type":"object","description":"Owner has pets.",
+	      "properties":{"name":{"type":"string","description":"Full name."}}
+	    },
+	    "Pet":{
+	      "type":"object","description":"Pet is a pet in store.",
+	      "properties":{
+	        "id":{"type":"integer","format":"int64","description":"ID is a unique identifier."},
+	        "kind":{"type":"string","description":"Kind of animal."},
+	        "name":{"type":"string","description":"Name of pet."},
+	        "owner":{"$ref":"#/definitions/Owner"}
+	      }
+	    }
+	  }
+	}`), data)
+
+	assert.Error(t, swgen.NewGenerator().ReflectComments("internal/missing"))
+}
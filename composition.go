@@ -0,0 +1,333 @@
+package swgen
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/refl"
+	"github.com/swaggest/swgen/internal/naming"
+)
+
+// OneOfExposer exposes variants of polymorphic type, value should match exactly one of them.
+//
+// Variants are reflected into definitions, type is rendered as "x-oneOf" in Swagger 2.0
+// and as "oneOf" in OpenAPI 3.0 and JSON Schema.
+type OneOfExposer interface {
+	SwaggerOneOf() []interface{}
+}
+
+// AnyOfExposer exposes variants of polymorphic type, value should match at least one of them.
+//
+// Type is rendered as "x-anyOf" in Swagger 2.0 and as "anyOf" in OpenAPI 3.0 and JSON Schema.
+type AnyOfExposer interface {
+	SwaggerAnyOf() []interface{}
+}
+
+// AllOfExposer exposes schemas that should all be matched by type value.
+type AllOfExposer interface {
+	SwaggerAllOf() []interface{}
+}
+
+const (
+	compositionOneOf = "oneOf"
+	compositionAnyOf = "anyOf"
+	compositionAllOf = "allOf"
+
+	// xProxyDiscriminator carries discriminator of OAS3 proxy schema, as JSON Schema has no such keyword.
+	xProxyDiscriminator = "x-swgen-discriminator"
+)
+
+// composition describes variants of polymorphic type.
+type composition struct {
+	kind     string
+	variants []interface{}
+
+	discriminator string   // name of property that identifies variant
+	values        []string // discriminator values of variants
+}
+
+// AddOneOf declares variants of a type, usually an interface, e.g. AddOneOf(new(Shape), Circle{}, Square{}).
+//
+// Fields of such type are rendered with "x-oneOf" in Swagger 2.0 and with "oneOf" in OpenAPI 3.0
+// (including OAS3 proxy) and JSON Schema.
+func (g *Generator) AddOneOf(typ interface{}, variants ...interface{}) *Generator {
+	return g.addComposition(typ, composition{kind: compositionOneOf, variants: variants})
+}
+
+// AddAnyOf declares variants of a type, value should match at least one of them.
+func (g *Generator) AddAnyOf(typ interface{}, variants ...interface{}) *Generator {
+	return g.addComposition(typ, composition{kind: compositionAnyOf, variants: variants})
+}
+
+// AddAllOf declares schemas that should all be matched by value of a type.
+func (g *Generator) AddAllOf(typ interface{}, variants ...interface{}) *Generator {
+	return g.addComposition(typ, composition{kind: compositionAllOf, variants: variants})
+}
+
+// AddDiscriminatedUnion declares a tagged union of variants identified by value of property,
+// e.g. AddDiscriminatedUnion(new(Shape), "kind", map[string]interface{}{"circle": Circle{}, "square": Square{}}).
+//
+// Fields of such type are rendered with "x-oneOf" and "discriminator" in Swagger 2.0, and with "oneOf"
+// and "discriminator" mapping in OpenAPI 3.0 (including OAS3 proxy). JSON Schema requires variant to match
+// discriminator value.
+func (g *Generator) AddDiscriminatedUnion(typ interface{}, propertyName string, variants map[string]interface{}) *Generator {
+	c := composition{kind: compositionOneOf, discriminator: propertyName}
+
+	for _, value := range sortedKeys(variants) {
+		c.values = append(c.values, value)
+		c.variants = append(c.variants, variants[value])
+	}
+
+	return g.addComposition(typ, c)
+}
+
+func (g *Generator) addComposition(typ interface{}, c composition) *Generator {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.ResetDocumentCache()
+
+	g.compositions[refl.GoType(refl.DeepIndirect(reflect.TypeOf(typ)))] = c
+
+	return g
+}
+
+// getComposition finds registered or exposed composition of a type.
+func (g *Generator) getComposition(t reflect.Type) (composition, bool) {
+	t = refl.DeepIndirect(t)
+
+	if c, found := g.compositions[refl.GoType(t)]; found {
+		return c, true
+	}
+
+	if t.Kind() == reflect.Interface {
+		return composition{}, false
+	}
+
+	v := reflect.New(t).Interface()
+
+	if e, ok := v.(OneOfExposer); ok {
+		return composition{kind: compositionOneOf, variants: e.SwaggerOneOf()}, true
+	}
+
+	if e, ok := v.(AnyOfExposer); ok {
+		return composition{kind: compositionAnyOf, variants: e.SwaggerAnyOf()}, true
+	}
+
+	if e, ok := v.(AllOfExposer); ok {
+		return composition{kind: compositionAllOf, variants: e.SwaggerAllOf()}, true
+	}
+
+	return composition{}, false
+}
+
+// compositionSchema reflects variants of polymorphic type into definition and returns reference to it.
+func (g *Generator) compositionSchema(t reflect.Type, c composition, fallbackRef string) SchemaObj {
+	t = refl.DeepIndirect(t)
+	name := g.makeNameForType(t, g.reflectTypeReliableName(t, fallbackRef))
+	ref := SchemaObj{Ref: refDefinitionPrefix + name, TypeName: name}
+
+	if g.defExists(t) {
+		return ref
+	}
+
+	def := SchemaObj{TypeName: name}
+	def.Description = g.comment(t, "")
+
+	// Definition is registered before variants are reflected to support recursive types.
+	g.definitions[refl.GoType(t)] = def
+
+	variants := make([]SchemaObj, 0, len(c.variants))
+
+	for i, v := range c.variants {
+		if v == nil {
+			continue
+		}
+
+		variant := g.genSchemaForType(reflect.TypeOf(v), "")
+
+		// Inline variant is named as definition to be referenced by discriminator mapping.
+		if c.discriminator != "" && variant.Ref == "" {
+			variant = g.inlineVariant(t, name, c.values[i], variant)
+		}
+
+		variants = append(variants, variant)
+
+		if c.discriminator != "" && variant.Ref != "" {
+			if def.DiscriminatorMapping == nil {
+				def.DiscriminatorMapping = make(map[string]string, len(c.values))
+			}
+
+			def.DiscriminatorMapping[c.values[i]] = variant.Ref
+		}
+	}
+
+	if c.discriminator != "" {
+		// Swagger 2.0 requires discriminator to be a required property of schema.
+		property := schemaFromCommonName(commonNameString)
+
+		for _, value := range c.values {
+			property.Enum.Enum = append(property.Enum.Enum, value)
+		}
+
+		def.Type = "object"
+		def.Discriminator = c.discriminator
+		def.Required = []string{c.discriminator}
+		def.Properties = map[string]SchemaObj{c.discriminator: property}
+	}
+
+	switch c.kind {
+	case compositionOneOf:
+		def.OneOf = variants
+	case compositionAnyOf:
+		def.AnyOf = variants
+	case compositionAllOf:
+		def.AllOf = variants
+	}
+
+	if g.reflectGoTypes {
+		def.GoType = string(refl.GoType(t))
+	}
+
+	g.definitions[refl.GoType(t)] = def
+
+	return ref
+}
+
+// inlineVariant registers schema of anonymous variant of discriminated union as definition named after
+// union and discriminator value, e.g. "ShapeTriangle", and returns reference to it.
+func (g *Generator) inlineVariant(union reflect.Type, unionName, value string, s SchemaObj) SchemaObj {
+	name := unionName + naming.Exported(value)
+
+	taken := make(map[string]bool, len(g.definitions))
+	for _, def := range g.definitions {
+		taken[def.TypeName] = true
+	}
+
+	for i := 2; taken[name]; i++ {
+		name = unionName + naming.Exported(value) + strconv.Itoa(i)
+	}
+
+	s.TypeName = name
+	g.definitions[refl.TypeString(string(refl.GoType(union))+"#"+value)] = s
+
+	return SchemaObj{Ref: refDefinitionPrefix + name, TypeName: name}
+}
+
+// proxyComposition renders compositions in schemas of OAS3 proxy, variants are reflected into component schemas.
+func (g *Generator) proxyComposition(proxy *openapi3.Reflector) jsonschema.InterceptTypeFunc {
+	inProgress := make(map[reflect.Type]bool) // Compositions being reflected, to break recursion.
+
+	return func(v reflect.Value, s *jsonschema.Schema) (bool, error) {
+		t := refl.DeepIndirect(v.Type())
+
+		c, ok := g.getComposition(t)
+		if !ok {
+			return false, nil
+		}
+
+		// Recursive composition is a reference to definition that is completed by outer reflection.
+		if inProgress[t] {
+			return true, nil
+		}
+
+		inProgress[t] = true
+		defer delete(inProgress, t)
+
+		collect := func(name string, schema jsonschema.Schema) {
+			schemas := proxy.SpecEns().ComponentsEns().SchemasEns()
+			if _, exists := schemas.MapOfSchemaOrRefValues[name]; exists {
+				return
+			}
+
+			if schema.ReflectType != nil && inProgress[refl.DeepIndirect(schema.ReflectType)] {
+				return
+			}
+
+			sr := openapi3.SchemaOrRef{}
+			sr.FromJSONSchema(schema.ToSchemaOrBool())
+			schemas.WithMapOfSchemaOrRefValuesItem(name, sr)
+		}
+
+		variants := make([]jsonschema.SchemaOrBool, 0, len(c.variants))
+		mapping := make(map[string]string)
+
+		for i, variant := range c.variants {
+			if variant == nil {
+				continue
+			}
+
+			vs, err := proxy.Reflect(variant, jsonschema.DefinitionsPrefix(refComponentsPrefix),
+				jsonschema.RootRef, jsonschema.CollectDefinitions(collect))
+			if err != nil {
+				return true, err
+			}
+
+			variants = append(variants, vs.ToSchemaOrBool())
+
+			if c.discriminator != "" && vs.Ref != nil {
+				mapping[c.values[i]] = *vs.Ref
+			}
+		}
+
+		if c.discriminator != "" {
+			property := jsonschema.Schema{}
+			property.WithType(jsonschema.String.Type())
+
+			for _, value := range c.values {
+				property.Enum = append(property.Enum, value)
+			}
+
+			s.WithType(jsonschema.Object.Type())
+			s.WithRequired(c.discriminator)
+			s.WithPropertiesItem(c.discriminator, property.ToSchemaOrBool())
+
+			d := openapi3.Discriminator{PropertyName: c.discriminator}
+			if len(mapping) > 0 {
+				d.Mapping = mapping
+			}
+
+			// Discriminator is moved from extension to schema keyword by proxyDiscriminators.
+			s.WithExtraPropertiesItem(xProxyDiscriminator, d)
+		}
+
+		switch c.kind {
+		case compositionOneOf:
+			s.WithOneOf(variants...)
+		case compositionAnyOf:
+			s.WithAnyOf(variants...)
+		case compositionAllOf:
+			s.WithAllOf(variants...)
+		}
+
+		return true, nil
+	}
+}
+
+// proxyDiscriminators sets discriminators of compositions in component schemas of OAS3 proxy.
+func proxyDiscriminators(proxy *openapi3.Reflector) {
+	if proxy.Spec == nil || proxy.Spec.Components == nil || proxy.Spec.Components.Schemas == nil {
+		return
+	}
+
+	for _, sr := range proxy.Spec.Components.Schemas.MapOfSchemaOrRefValues {
+		if sr.Schema == nil {
+			continue
+		}
+
+		d, ok := sr.Schema.MapOfAnything[xProxyDiscriminator].(openapi3.Discriminator)
+		if !ok {
+			continue
+		}
+
+		delete(sr.Schema.MapOfAnything, xProxyDiscriminator)
+
+		if len(sr.Schema.MapOfAnything) == 0 {
+			sr.Schema.MapOfAnything = nil
+		}
+
+		sr.Schema.WithDiscriminator(d)
+	}
+}
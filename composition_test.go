@@ -0,0 +1,284 @@
+package swgen_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/swgen"
+)
+
+type shape interface {
+	Area() float64
+}
+
+type circle struct {
+	Radius float64 `json:"radius" required:"true"`
+}
+
+func (c circle) Area() float64 { return 3.14 * c.Radius * c.Radius }
+
+type square struct {
+	Side float64 `json:"side" required:"true"`
+}
+
+func (s square) Area() float64 { return s.Side * s.Side }
+
+type identifier struct{}
+
+func (identifier) SwaggerAnyOf() []interface{} {
+	return []interface{}{"", 0}
+}
+
+type caption struct {
+	Text string `json:"text"`
+}
+
+type labeledCircle struct{}
+
+func (labeledCircle) SwaggerAllOf() []interface{} {
+	return []interface{}{circle{}, caption{}}
+}
+
+type drawing struct {
+	ID     identifier    `json:"id"`
+	Main   shape         `json:"main"`
+	Shapes []shape       `json:"shapes"`
+	Badge  labeledCircle `json:"badge"`
+}
+
+func compositionGenerator() *swgen.Generator {
+	return addComposition(swgen.NewGenerator())
+}
+
+func addComposition(g *swgen.Generator) *swgen.Generator {
+	g.AddOneOf(new(shape), circle{}, square{})
+	g.SetPathItem(swgen.PathItemInfo{Path: "/drawing", Method: http.MethodGet, Response: drawing{Main: square{}}})
+
+	return g
+}
+
+func TestGenerator_AddOneOf(t *testing.T) {
+	reflector := openapi3.Reflector{}
+
+	g := swgen.NewGenerator()
+	g.SetOAS3Proxy(&reflector)
+	addComposition(g)
+
+	data, err := g.GenDocument()
+	require.NoError(t, err)
+
+	var doc swgen.Document
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assertjson.EqualMarshal(t, []byte(`{
+	  "caption":{"type":"object","properties":{"text":{"type":"string"}}},
+	  "circle":{"type":"object","required":["radius"],"properties":{"radius":{"type":"number","format":"double"}}},
+	  "drawing":{
+	    "type":"object",
+	    "properties":{
+	      "badge":{"$ref":"#/definitions/labeledCircle"},"id":{"$ref":"#/definitions/identifier"},
+	      "main":{"$ref":"#/definitions/shape"},
+	      "shapes":{"type":"array","items":{"$ref":"#/definitions/shape"}}
+	    }
+	  },
+	  "identifier":{"x-anyOf":[{"type":"string"},{"type":"integer","format":"int32"}]},
+	  "labeledCircle":{"allOf":[{"$ref":"#/definitions/circle"},{"$ref":"#/definitions/caption"}]},
+	  "shape":{"x-oneOf":[{"$ref":"#/definitions/circle"},{"$ref":"#/definitions/square"}]},
+	  "square":{"type":"object","required":["side"],"properties":{"side":{"type":"number","format":"double"}}}
+	}`), doc.Definitions)
+
+	oas3 := g.DocumentOAS3()
+	assertjson.EqualMarshal(t, []byte(`{
+	  "identifier":{"anyOf":[{"type":"string"},{"type":"integer","format":"int32"}]},
+	  "labeledCircle":{"allOf":[{"$ref":"#/components/schemas/circle"},{"$ref":"#/components/schemas/caption"}]},
+	  "shape":{"oneOf":[{"$ref":"#/components/schemas/circle"},{"$ref":"#/components/schemas/square"}]}
+	}`), map[string]swgen.SchemaObj{
+		"identifier":    oas3.Components.Schemas["identifier"],
+		"labeledCircle": oas3.Components.Schemas["labeledCircle"],
+		"shape":         oas3.Components.Schemas["shape"],
+	})
+
+	schemas := reflector.Spec.Components.Schemas.MapOfSchemaOrRefValues
+	assertjson.EqualMarshal(t, []byte(`{
+	  "oneOf":[{"$ref":"#/components/schemas/SwgenTestCircle"},{"$ref":"#/components/schemas/SwgenTestSquare"}],
+	  "nullable":true
+	}`), schemas["SwgenTestShape"])
+	assertjson.EqualMarshal(t, []byte(`{"anyOf":[{"type":"string"},{"type":"integer"}]}`),
+		schemas["SwgenTestIdentifier"])
+	assertjson.EqualMarshal(t, []byte(`{
+	  "allOf":[{"$ref":"#/components/schemas/SwgenTestCircle"},{"$ref":"#/components/schemas/SwgenTestCaption"}]
+	}`), schemas["SwgenTestLabeledCircle"])
+	assertjson.EqualMarshal(t, []byte(`{"required":["side"],"type":"object","properties":{"side":{"type":"number"}}}`),
+		schemas["SwgenTestSquare"])
+}
+
+func TestGenerator_JSONSchema_composition(t *testing.T) {
+	g := compositionGenerator()
+
+	var schema map[string]interface{}
+
+	require.NoError(t, g.WalkJSONSchemaResponses(func(path, method string, statusCode int, s map[string]interface{}) {
+		schema = s
+	}))
+
+	definitions, ok := schema["definitions"].(map[string]map[string]interface{})
+	require.True(t, ok)
+
+	assertjson.EqualMarshal(t, []byte(`{
+	  "identifier":{"anyOf":[{"type":"string"},{"format":"int32","type":"integer"}]},
+	  "labeledCircle":{"allOf":[{"$ref":"#/definitions/circle"},{"$ref":"#/definitions/caption"}]},
+	  "shape":{"oneOf":[{"$ref":"#/definitions/circle"},{"$ref":"#/definitions/square"}]}
+	}`), map[string]interface{}{
+		"identifier":    definitions["identifier"],
+		"labeledCircle": definitions["labeledCircle"],
+		"shape":         definitions["shape"],
+	})
+
+	v := swgen.NewResponseValidator(g)
+	req := httptest.NewRequest(http.MethodGet, "/drawing", nil)
+
+	assert.NoError(t, v.ValidateResponse(req, http.StatusOK, nil,
+		[]byte(`{"id":"abc","main":{"radius":1},"badge":{"radius":2,"text":"b"}}`)))
+	assert.EqualError(t, v.ValidateResponse(req, http.StatusOK, nil, []byte(`{"id":true}`)),
+		"invalid response: response /id: expected string, but got boolean, "+
+			"response /id: expected integer, but got boolean")
+}
+
+type event interface {
+	isEvent()
+}
+
+type eventCreated struct {
+	Kind string `json:"kind"`
+	ID   int    `json:"id" required:"true"`
+}
+
+func (eventCreated) isEvent() {}
+
+type eventDeleted struct {
+	Kind   string `json:"kind"`
+	ID     int    `json:"id" required:"true"`
+	Reason string `json:"reason"`
+}
+
+func (eventDeleted) isEvent() {}
+
+func unionGenerator() *swgen.Generator {
+	return addUnion(swgen.NewGenerator())
+}
+
+func addUnion(g *swgen.Generator) *swgen.Generator {
+	g.AddDiscriminatedUnion(new(event), "kind", map[string]interface{}{
+		"created": eventCreated{},
+		"deleted": eventDeleted{},
+	})
+	g.SetPathItem(swgen.PathItemInfo{Path: "/events", Method: http.MethodPost, Request: new(event), Response: []event{}})
+
+	return g
+}
+
+func TestGenerator_AddDiscriminatedUnion(t *testing.T) {
+	reflector := openapi3.Reflector{}
+
+	g := swgen.NewGenerator()
+	g.SetOAS3Proxy(&reflector)
+	addUnion(g)
+
+	data, err := g.GenDocument()
+	require.NoError(t, err)
+
+	var doc swgen.Document
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assertjson.EqualMarshal(t, []byte(`{
+	  "type":"object",
+	  "required":["kind"],
+	  "properties":{"kind":{"type":"string","enum":["created","deleted"]}},
+	  "x-oneOf":[{"$ref":"#/definitions/eventCreated"},{"$ref":"#/definitions/eventDeleted"}],
+	  "discriminator":"kind",
+	  "x-discriminator-mapping":{"created":"#/definitions/eventCreated","deleted":"#/definitions/eventDeleted"}
+	}`), doc.Definitions["event"])
+	assertjson.EqualMarshal(t, []byte(`{"in":"body","name":"body","required":true,"schema":{"$ref":"#/definitions/event"}}`),
+		doc.Paths["/events"].Post.Parameters[0])
+
+	oas3 := g.DocumentOAS3()
+	assertjson.EqualMarshal(t, []byte(`{
+	  "type":"object",
+	  "required":["kind"],
+	  "properties":{"kind":{"type":"string","enum":["created","deleted"]}},
+	  "oneOf":[{"$ref":"#/components/schemas/eventCreated"},{"$ref":"#/components/schemas/eventDeleted"}],
+	  "discriminator":{
+	    "propertyName":"kind",
+	    "mapping":{"created":"#/components/schemas/eventCreated","deleted":"#/components/schemas/eventDeleted"}
+	  }
+	}`), oas3.Components.Schemas["event"])
+
+	assertjson.EqualMarshal(t, []byte(`{
+	  "type":"object",
+	  "required":["kind"],
+	  "properties":{"kind":{"type":"string","enum":["created","deleted"]}},
+	  "oneOf":[{"$ref":"#/components/schemas/SwgenTestEventCreated"},{"$ref":"#/components/schemas/SwgenTestEventDeleted"}],
+	  "discriminator":{
+	    "propertyName":"kind",
+	    "mapping":{
+	      "created":"#/components/schemas/SwgenTestEventCreated",
+	      "deleted":"#/components/schemas/SwgenTestEventDeleted"
+	    }
+	  }
+	}`), reflector.Spec.Components.Schemas.MapOfSchemaOrRefValues["SwgenTestEvent"])
+}
+
+func TestGenerator_AddDiscriminatedUnion_inlineVariant(t *testing.T) {
+	g := swgen.NewGenerator()
+	g.AddDiscriminatedUnion(new(event), "kind", map[string]interface{}{
+		"created": eventCreated{},
+		"moved":   map[string]string{},
+	})
+	g.SetPathItem(swgen.PathItemInfo{Path: "/events", Method: http.MethodPost, Request: new(event)})
+
+	data, err := g.GenDocument()
+	require.NoError(t, err)
+
+	var doc swgen.Document
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assertjson.EqualMarshal(t, []byte(`{
+	  "type":"object",
+	  "required":["kind"],
+	  "properties":{"kind":{"type":"string","enum":["created","moved"]}},
+	  "x-oneOf":[{"$ref":"#/definitions/eventCreated"},{"$ref":"#/definitions/eventMoved"}],
+	  "discriminator":"kind",
+	  "x-discriminator-mapping":{"created":"#/definitions/eventCreated","moved":"#/definitions/eventMoved"}
+	}`), doc.Definitions["event"])
+	assertjson.EqualMarshal(t, []byte(`{"type":"object","additionalProperties":{"type":"string"}}`),
+		doc.Definitions["eventMoved"])
+
+	v := swgen.NewRequestValidator(g)
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(`{"kind":"moved","to":"archive"}`))
+	req.Header.Set("Content-Type", "application/json")
+	assert.NoError(t, v.ValidateRequest(req))
+}
+
+func TestGenerator_JSONSchema_discriminatedUnion(t *testing.T) {
+	g := unionGenerator()
+	v := swgen.NewRequestValidator(g)
+
+	validate := func(body string) error {
+		req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		return v.ValidateRequest(req)
+	}
+
+	assert.NoError(t, validate(`{"kind":"created","id":1}`))
+	assert.NoError(t, validate(`{"kind":"deleted","id":1,"reason":"spam"}`))
+	assert.Error(t, validate(`{"kind":"moved","id":1}`))
+	assert.Error(t, validate(`{"kind":"deleted"}`))
+}
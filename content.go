@@ -0,0 +1,328 @@
+package swgen
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+func isJSONMIME(mime string) bool {
+	return strings.Contains(mime, "json")
+}
+
+func isFormMIME(mime string) bool {
+	return mime == mimeFormURLEncoded || mime == mimeMultipartForm
+}
+
+// appendMIMETypes adds MIME types that are not in the list yet.
+func appendMIMETypes(list []string, mimeTypes ...string) []string {
+	for _, mime := range mimeTypes {
+		found := false
+
+		for _, m := range list {
+			if m == mime {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			list = append(list, mime)
+		}
+	}
+
+	return list
+}
+
+// primaryMIME returns the first JSON MIME type or the first MIME type that satisfies fallback.
+func primaryMIME(mimeTypes []string, fallback func(mime string) bool) string {
+	for _, mime := range mimeTypes {
+		if isJSONMIME(mime) {
+			return mime
+		}
+	}
+
+	for _, mime := range mimeTypes {
+		if fallback(mime) {
+			return mime
+		}
+	}
+
+	return ""
+}
+
+// contentSamples merges samples by MIME type with JSON sample, that is used unless overridden.
+func contentSamples(samples map[string]interface{}, jsonSample interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(samples)+1)
+
+	if jsonSample != nil {
+		res[mimeJSON] = jsonSample
+	}
+
+	for mime, sample := range samples {
+		res[mime] = sample
+	}
+
+	return res
+}
+
+// setRequestContent reflects request samples by MIME type and returns sample of Swagger 2.0 body parameter.
+//
+// Form samples become "formData" parameters if there is no other sample for body parameter.
+func (g *Generator) setRequestContent(info PathItemInfo, op *OperationObj, body interface{}) interface{} {
+	samples := contentSamples(info.RequestContent, body)
+	mimeTypes := sortedKeys(samples)
+	primary := primaryMIME(mimeTypes, func(mime string) bool { return !isFormMIME(mime) })
+
+	op.Consumes = appendMIMETypes(op.Consumes, mimeTypes...)
+	op.requestContent = make(map[string]*SchemaObj, len(mimeTypes))
+
+	formParams := primary != ""
+
+	for _, mime := range mimeTypes {
+		if mime == primary {
+			continue
+		}
+
+		sample := samples[mime]
+
+		if !isFormMIME(mime) || sample == nil {
+			s := g.requestContentSchema(sample)
+			op.requestContent[mime] = &s
+
+			continue
+		}
+
+		_, params := g.parseParameters(sample)
+
+		form, _ := formSchema(params)
+		if form == nil {
+			form = NewSchemaObj("object", "")
+		}
+
+		op.requestContent[mime] = form
+
+		if !formParams {
+			op.Parameters = appendParams(op.Parameters, params, "formData")
+			formParams = true
+		}
+	}
+
+	if primary == "" {
+		return nil
+	}
+
+	if samples[primary] == nil {
+		s := g.requestContentSchema(nil)
+		op.Parameters = append(op.Parameters, ParamObj{Name: "body", In: "body", Required: true, Schema: &s})
+	}
+
+	return samples[primary]
+}
+
+// requestContentSchema reflects request body sample, nil sample stands for binary body.
+func (g *Generator) requestContentSchema(sample interface{}) SchemaObj {
+	if sample == nil {
+		s := schemaFromCommonName(commonNameString)
+		s.Format = "binary"
+
+		return s
+	}
+
+	return g.parseDefinition(sample)
+}
+
+// appendParams adds parameters located in "in" that are not in the list yet.
+func appendParams(list []ParamObj, params []ParamObj, in string) []ParamObj {
+	for _, p := range params {
+		if p.In != in {
+			continue
+		}
+
+		found := false
+
+		for _, e := range list {
+			if e.In == p.In && e.Name == p.Name {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			list = append(list, p)
+		}
+	}
+
+	return list
+}
+
+// setResponseContent reflects successful response samples by MIME type.
+func (g *Generator) setResponseContent(info PathItemInfo, op *OperationObj, statusCode int, response interface{}) {
+	samples := contentSamples(info.ResponseContent, response)
+	mimeTypes := sortedKeys(samples)
+	primary := primaryMIME(mimeTypes, func(string) bool { return true })
+
+	op.Produces = appendMIMETypes(op.Produces, mimeTypes...)
+
+	resp := op.Responses[statusCode]
+	resp.content = make(map[string]*SchemaObj, len(mimeTypes))
+
+	for _, mime := range mimeTypes {
+		sample := samples[mime]
+		s := g.responseContentSchema(sample)
+
+		if mime != primary {
+			resp.content[mime] = &s
+
+			continue
+		}
+
+		// Schema of Response is already reflected.
+		if _, overridden := info.ResponseContent[mime]; overridden || response == nil {
+			resp.Schema = &s
+		}
+	}
+
+	op.Responses[statusCode] = resp
+}
+
+// responseContentSchema reflects response body sample, nil sample stands for binary body.
+func (g *Generator) responseContentSchema(sample interface{}) SchemaObj {
+	if sample == nil {
+		s := SchemaObj{}
+		s.Type = "file"
+
+		return s
+	}
+
+	return g.parseDefinition(sample)
+}
+
+// setOpenAPIContent adds request and response samples by MIME type to operation of OpenAPI 3.0 reflector.
+func setOpenAPIContent(info PathItemInfo, r *openapi3.Reflector, op *openapi3.Operation) error {
+	if len(info.RequestContent) > 0 {
+		content := make(map[string]openapi3.MediaType, len(info.RequestContent))
+
+		for _, mime := range sortedKeys(info.RequestContent) {
+			mt, err := openAPIRequestContent(r, info.RequestContent[mime], info.Method, mime)
+			if err != nil {
+				return err
+			}
+
+			content[mime] = mt
+		}
+
+		body := op.RequestBodyEns().RequestBodyEns()
+
+		for mime := range body.Content {
+			// Form content of Request is replaced with form samples.
+			if isFormMIME(mime) && hasFormSample(info.RequestContent) {
+				delete(body.Content, mime)
+			}
+		}
+
+		for mime, mt := range content {
+			body.WithContentItem(mime, mt)
+		}
+
+		body.WithRequired(true)
+	}
+
+	if len(info.ResponseContent) == 0 {
+		return nil
+	}
+
+	httpStatus := http.StatusOK
+	if info.SuccessfulResponseCode != 0 {
+		httpStatus = info.SuccessfulResponseCode
+	}
+
+	code := strconv.Itoa(httpStatus)
+
+	resp := op.Responses.MapOfResponseOrRefValues[code].Response
+	if resp == nil {
+		resp = &openapi3.Response{Description: http.StatusText(httpStatus)}
+		op.Responses.WithMapOfResponseOrRefValuesItem(code, openapi3.ResponseOrRef{Response: resp})
+	}
+
+	if resp.Content == nil {
+		resp.Content = make(map[string]openapi3.MediaType, len(info.ResponseContent))
+	}
+
+	for _, mime := range sortedKeys(info.ResponseContent) {
+		sample := info.ResponseContent[mime]
+		if sample == nil {
+			resp.Content[mime] = openAPIBinary()
+
+			continue
+		}
+
+		tmp := openapi3.Operation{}
+
+		err := r.SetupResponse(openapi3.OperationContext{
+			Operation:       &tmp,
+			Output:          sample,
+			HTTPStatus:      httpStatus,
+			RespContentType: mime,
+		})
+		if err != nil {
+			return err
+		}
+
+		if mt, ok := tmp.Responses.MapOfResponseOrRefValues[code].Response.Content[mime]; ok {
+			resp.Content[mime] = mt
+		}
+	}
+
+	return nil
+}
+
+// openAPIRequestContent reflects request body sample with OpenAPI 3.0 reflector.
+func openAPIRequestContent(r *openapi3.Reflector, sample interface{}, method, mime string) (openapi3.MediaType, error) {
+	if sample == nil {
+		return openAPIBinary(), nil
+	}
+
+	tmp := openapi3.Operation{}
+
+	// Request body is reflected for any method, as content samples are declared explicitly.
+	if err := r.SetRequest(&tmp, sample, http.MethodPost); err != nil {
+		return openapi3.MediaType{}, err
+	}
+
+	content := tmp.RequestBodyEns().RequestBodyEns().Content
+
+	for _, m := range []string{mime, mimeMultipartForm, mimeFormURLEncoded, mimeJSON} {
+		if isFormMIME(m) != isFormMIME(mime) {
+			continue
+		}
+
+		if mt, ok := content[m]; ok {
+			return mt, nil
+		}
+	}
+
+	return openapi3.MediaType{}, fmt.Errorf("no %s request body in %T for %s", mime, sample, method)
+}
+
+func hasFormSample(samples map[string]interface{}) bool {
+	for mime, sample := range samples {
+		if isFormMIME(mime) && sample != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func openAPIBinary() openapi3.MediaType {
+	s := openapi3.Schema{}
+	s.WithType(openapi3.SchemaTypeString).WithFormat("binary")
+
+	return openapi3.MediaType{Schema: &openapi3.SchemaOrRef{Schema: &s}}
+}
@@ -0,0 +1,136 @@
+package swgen_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/swgen"
+)
+
+type contentReport struct {
+	ID    int    `path:"id"`
+	Title string `json:"title"`
+}
+
+type contentReportForm struct {
+	Title string `formData:"title" required:"true"`
+}
+
+type contentReportRow struct {
+	Line string `json:"line"`
+}
+
+type contentUpload struct {
+	Name string `formData:"name"`
+}
+
+func TestPathItemInfo_content(t *testing.T) {
+	reflector := openapi3.Reflector{}
+
+	g := swgen.NewGenerator()
+	g.SetOAS3Proxy(&reflector)
+	g.SetPathItem(swgen.PathItemInfo{
+		Path: "/reports/{id}", Method: http.MethodPut, Request: new(contentReport), Response: new(exampleOrder),
+		RequestContent: map[string]interface{}{"application/x-www-form-urlencoded": new(contentReportForm)},
+		ResponseContent: map[string]interface{}{
+			"text/csv":        new(contentReportRow),
+			"application/pdf": nil,
+		},
+	})
+	g.SetPathItem(swgen.PathItemInfo{
+		Path: "/uploads", Method: http.MethodPost,
+		RequestContent: map[string]interface{}{
+			"multipart/form-data":      new(contentUpload),
+			"application/octet-stream": nil,
+		},
+		ResponseContent: map[string]interface{}{"image/png": nil},
+	})
+	g.SetPathItem(swgen.PathItemInfo{
+		Path: "/forms", Method: http.MethodPost,
+		RequestContent: map[string]interface{}{"application/x-www-form-urlencoded": new(contentReportForm)},
+	})
+
+	data, err := g.GenDocument()
+	require.NoError(t, err)
+
+	var doc swgen.Document
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	put := doc.Paths["/reports/{id}"].Put
+	assertjson.EqualMarshal(t, []byte(`{
+	  "summary":"","description":"",
+	  "consumes":["application/json","application/x-www-form-urlencoded"],
+	  "produces":["application/json","application/pdf","text/csv"],
+	  "parameters":[
+	    {"type":"integer","format":"int32","name":"id","in":"path","required":true},
+	    {"name":"body","in":"body","required":true,"schema":{"$ref":"#/definitions/contentReport"}}
+	  ],
+	  "responses":{"200":{"description":"OK","schema":{"$ref":"#/definitions/exampleOrder"}}}
+	}`), put)
+
+	post := doc.Paths["/uploads"].Post
+	assertjson.EqualMarshal(t, []byte(`{
+	  "summary":"","description":"",
+	  "consumes":["application/octet-stream","multipart/form-data"],
+	  "produces":["image/png"],
+	  "parameters":[
+	    {"name":"body","in":"body","required":true,"schema":{"type":"string","format":"binary"}}
+	  ],
+	  "responses":{"200":{"description":"OK","schema":{"type":"file"}}}
+	}`), post)
+
+	assertjson.EqualMarshal(t, []byte(`[
+	  {"type":"string","name":"title","in":"formData","required":true}
+	]`), doc.Paths["/forms"].Post.Parameters)
+
+	// Definitions of other samples are kept for OpenAPI 3.0 components, though Swagger 2.0 paths do not reference them.
+	assertjson.EqualMarshal(t, []byte(`{"type":"object","properties":{"line":{"type":"string"}}}`),
+		doc.Definitions["contentReportRow"])
+
+	oas3 := g.DocumentOAS3()
+	assertjson.EqualMarshal(t, []byte(`{
+	  "required":true,
+	  "content":{
+	    "application/json":{"schema":{"$ref":"#/components/schemas/contentReport"}},
+	    "application/x-www-form-urlencoded":{"schema":{"required":["title"],"type":"object","properties":{"title":{"type":"string"}}}}
+	  }
+	}`), oas3.Paths["/reports/{id}"].Put.RequestBody)
+	assertjson.EqualMarshal(t, []byte(`{
+	  "application/json":{"schema":{"$ref":"#/components/schemas/exampleOrder"}},
+	  "application/pdf":{"schema":{"type":"string","format":"binary"}},
+	  "text/csv":{"schema":{"$ref":"#/components/schemas/contentReportRow"}}
+	}`), oas3.Paths["/reports/{id}"].Put.Responses[http.StatusOK].Content)
+	assertjson.EqualMarshal(t, []byte(`{
+	  "required":true,
+	  "content":{
+	    "application/octet-stream":{"schema":{"type":"string","format":"binary"}},
+	    "multipart/form-data":{"schema":{"type":"object","properties":{"name":{"type":"string"}}}}
+	  }
+	}`), oas3.Paths["/uploads"].Post.RequestBody)
+
+	assertjson.EqualMarshal(t, []byte(`{
+	  "required":true,
+	  "content":{
+	    "application/x-www-form-urlencoded":{"schema":{"required":["title"],"type":"object","properties":{"title":{"type":"string"}}}}
+	  }
+	}`), oas3.Paths["/forms"].Post.RequestBody)
+
+	proxied := reflector.Spec.Paths.MapOfPathItemValues["/reports/{id}"].MapOfOperationValues["put"]
+	assertjson.EqualMarshal(t, []byte(`{
+	  "required":true,
+	  "content":{
+	    "application/json":{"schema":{"$ref":"#/components/schemas/SwgenTestContentReport"}},
+	    "application/x-www-form-urlencoded":{"schema":{"$ref":"#/components/schemas/FormDataSwgenTestContentReportForm"}}
+	  }
+	}`), proxied.RequestBody.RequestBody)
+	assertjson.EqualMarshal(t, []byte(`{
+	  "application/json":{"schema":{"$ref":"#/components/schemas/SwgenTestExampleOrder"}},
+	  "application/pdf":{"schema":{"type":"string","format":"binary"}},
+	  "text/csv":{"schema":{"$ref":"#/components/schemas/SwgenTestContentReportRow"}}
+	}`), proxied.Responses.MapOfResponseOrRefValues["200"].Response.Content)
+}
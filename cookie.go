@@ -0,0 +1,121 @@
+package swgen
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/swaggest/refl"
+)
+
+// CookieParamPolicy defines rendering of cookie parameters in Swagger 2.0 document that has no cookie location.
+//
+// Cookie parameters are always kept in OpenAPI 3.0 document, request validation and JSON Schema request groups.
+type CookieParamPolicy int
+
+const (
+	// CookieParamsKeep renders cookie parameters with "in": "cookie", this is invalid in Swagger 2.0 (default).
+	CookieParamsKeep CookieParamPolicy = iota
+	// CookieParamsDrop omits cookie parameters from Swagger 2.0 document.
+	CookieParamsDrop
+	// CookieParamsExtension renders cookie parameters as headers with "x-in": "cookie" vendor extension.
+	CookieParamsExtension
+	// CookieParamsFail makes TrySetPathItem fail for operations with cookie parameters,
+	// GenDocument fails if such operations were registered before policy was set.
+	CookieParamsFail
+)
+
+const xIn = "x-in"
+
+var errCookieParam = errors.New("cookie parameter is not supported in Swagger 2.0")
+
+// SetCookieParamPolicy sets rendering of cookie parameters in Swagger 2.0 document, default is CookieParamsKeep.
+func (g *Generator) SetCookieParamPolicy(policy CookieParamPolicy) *Generator {
+	g.mu.Lock()
+	g.cookieParamPolicy = policy
+	g.ResetDocumentCache()
+	g.mu.Unlock()
+
+	return g
+}
+
+// paramIn returns location of parameter, cookie parameters rendered as headers are recognized by "x-in".
+func paramIn(p ParamObj) string {
+	if in, ok := p.data[xIn].(string); ok && in != "" {
+		return in
+	}
+
+	return p.In
+}
+
+// checkCookieParams rejects cookie parameters of request with CookieParamsFail policy.
+//
+// Cookies of response structures are not request parameters and are not checked.
+func (g *Generator) checkCookieParams(request interface{}, params []ParamObj) {
+	if g.cookieParamPolicy != CookieParamsFail {
+		return
+	}
+
+	t := reflect.TypeOf(request)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	fields := make(map[string]string)
+	cookieFields(t, "", fields)
+
+	for _, p := range params {
+		if p.In == "cookie" {
+			g.addReflectError(FieldError{
+				GoType: string(refl.GoType(t)), Field: fields[p.Name], Value: p.Name, Err: errCookieParam,
+			})
+		}
+	}
+}
+
+// cookieFields collects paths of fields by names of cookie parameters, e.g. "Auth.Session".
+func cookieFields(t reflect.Type, prefix string, res map[string]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous {
+			cookieFields(field.Type, prefix+field.Name+".", res)
+
+			continue
+		}
+
+		if name := strings.Split(field.Tag.Get("cookie"), ",")[0]; name != "" && name != "-" {
+			res[name] = prefix + field.Name
+		}
+	}
+}
+
+// cookieParamsError reports cookie parameters of operations registered before CookieParamsFail policy was set.
+func (g *Generator) cookieParamsError() error {
+	if g.cookieParamPolicy != CookieParamsFail {
+		return nil
+	}
+
+	for _, path := range sortedKeys(g.paths) {
+		ops := g.paths[path].Map()
+
+		for _, method := range sortedKeys(ops) {
+			for _, p := range ops[method].Parameters {
+				if p.In == "cookie" {
+					return fmt.Errorf("failed to render %s %s: %s: %w", method, path, p.Name, errCookieParam)
+				}
+			}
+		}
+	}
+
+	return nil
+}
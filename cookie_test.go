@@ -0,0 +1,109 @@
+package swgen_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/swgen"
+)
+
+type sessionRequest struct {
+	Session string `cookie:"session" required:"true" minLength:"8"`
+	Locale  string `header:"Accept-Language"`
+}
+
+func cookieParams(t *testing.T, g *swgen.Generator) []swgen.ParamObj {
+	t.Helper()
+
+	data, err := g.GenDocument()
+	require.NoError(t, err)
+
+	var doc swgen.Document
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	return doc.Paths["/profile"].Get.Parameters
+}
+
+func TestGenerator_SetCookieParamPolicy(t *testing.T) {
+	reflector := openapi3.Reflector{}
+
+	g := swgen.NewGenerator()
+	g.SetOAS3Proxy(&reflector)
+
+	op := g.SetPathItem(swgen.PathItemInfo{Path: "/profile", Method: http.MethodGet, Request: new(sessionRequest)})
+
+	assertjson.EqualMarshal(t, []byte(`[
+	  {"type":"string","minLength":8,"name":"session","in":"cookie","required":true},
+	  {"type":"string","name":"Accept-Language","in":"header"}
+	]`), cookieParams(t, g))
+
+	g.SetCookieParamPolicy(swgen.CookieParamsDrop)
+	assertjson.EqualMarshal(t, []byte(`[
+	  {"type":"string","name":"Accept-Language","in":"header"}
+	]`), cookieParams(t, g))
+
+	g.SetCookieParamPolicy(swgen.CookieParamsExtension)
+
+	params := cookieParams(t, g)
+	assertjson.EqualMarshal(t, []byte(`[
+	  {"type":"string","minLength":8,"name":"session","in":"header","required":true,"x-in":"cookie"},
+	  {"type":"string","name":"Accept-Language","in":"header"}
+	]`), params)
+
+	// Cookie parameters stay in OpenAPI 3.0 and JSON Schema request groups.
+	assertjson.EqualMarshal(t, []byte(`{
+	  "name":"session","in":"cookie","required":true,"schema":{"type":"string","minLength":8}
+	}`), g.DocumentOAS3().Paths["/profile"].Get.Parameters[0])
+
+	proxied := reflector.Spec.Paths.MapOfPathItemValues["/profile"].MapOfOperationValues["get"]
+	assert.Equal(t, openapi3.ParameterInCookie, proxied.Parameters[0].Parameter.In)
+
+	groups, err := g.GetJSONSchemaRequestGroups(op)
+	require.NoError(t, err)
+	assertjson.EqualMarshal(t, []byte(`{
+	  "$schema":"http://json-schema.org/draft-04/schema#","type":"object","required":["session"],
+	  "properties":{"session":{"type":"string","minLength":8}}
+	}`), groups["cookie"])
+
+	rendered := &swgen.OperationObj{Parameters: params}
+	groups, err = g.GetJSONSchemaRequestGroups(rendered)
+	require.NoError(t, err)
+	assertjson.EqualMarshal(t, []byte(`{
+	  "$schema":"http://json-schema.org/draft-04/schema#","type":"object","required":["session"],
+	  "properties":{"session":{"type":"string","minLength":8}}
+	}`), groups["cookie"])
+}
+
+func TestGenerator_SetCookieParamPolicy_fail(t *testing.T) {
+	g := swgen.NewGenerator().SetCookieParamPolicy(swgen.CookieParamsFail)
+
+	_, err := g.TrySetPathItem(swgen.PathItemInfo{Path: "/profile", Method: http.MethodGet, Request: new(sessionRequest)})
+	assert.EqualError(t, err, "failed to reflect GET /profile: "+
+		"github.com/swaggest/swgen_test.sessionRequest.Session: session: cookie parameter is not supported in Swagger 2.0")
+
+	// Cookies of response are not request parameters.
+	type sessionResponse struct {
+		Session string `cookie:"session"`
+		Name    string `json:"name"`
+	}
+
+	_, err = g.TrySetPathItem(swgen.PathItemInfo{Path: "/login", Method: http.MethodPost, Response: new(sessionResponse)})
+	assert.NoError(t, err)
+
+	// Policy set after registration fails rendering of Swagger 2.0 document.
+	g = swgen.NewGenerator()
+	g.SetPathItem(swgen.PathItemInfo{Path: "/profile", Method: http.MethodGet, Request: new(sessionRequest)})
+	g.SetCookieParamPolicy(swgen.CookieParamsFail)
+
+	_, err = g.GenDocument()
+	assert.EqualError(t, err, "failed to render GET /profile: session: cookie parameter is not supported in Swagger 2.0")
+
+	_, err = g.GenDocumentOAS3()
+	assert.NoError(t, err)
+}
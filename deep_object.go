@@ -0,0 +1,321 @@
+package swgen
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+// styleDeepObject serializes object parameter in query as "filter[status]=open&filter[owner]=42".
+const styleDeepObject = "deepObject"
+
+var (
+	errDeepObjectIn   = errors.New("deepObject style is only supported in query")
+	errDeepObjectType = errors.New("deepObject style requires struct, map or array of structs")
+)
+
+// deepObjectParam turns parameter into object parameter with deepObject style, schemaObj becomes generic object.
+func deepObjectParam(in string, param *ParamObj, schemaObj *SchemaObj) error {
+	if in != "query" {
+		return FieldError{Tag: "style", Value: styleDeepObject, Err: errDeepObjectIn}
+	}
+
+	isObject := schemaObj.Ref != "" || schemaObj.Type == "object"
+	isArrayOfObjects := schemaObj.Type == "array" && schemaObj.Items != nil &&
+		(schemaObj.Items.Ref != "" || schemaObj.Items.Type == "object")
+
+	if !isObject && !isArrayOfObjects {
+		return FieldError{Tag: "style", Value: styleDeepObject, Err: errDeepObjectType}
+	}
+
+	s := *schemaObj
+	param.Schema = &s
+	param.style = styleDeepObject
+
+	*schemaObj = SchemaObj{}
+	schemaObj.Type = "object"
+
+	return nil
+}
+
+// paramItems converts schema of array items into parameter items, nested arrays are separated with "csv" by default.
+func paramItems(s *SchemaObj) (*ParamItemObj, bool) {
+	if s.Ref != "" || s.Type == "object" {
+		return nil, false
+	}
+
+	items := &ParamItemObj{CommonFields: s.CommonFields}
+	items.Title = ""
+	items.Description = ""
+
+	if s.Type == "array" && s.Items != nil {
+		nested, ok := paramItems(s.Items)
+		if !ok {
+			return nil, false
+		}
+
+		items.Items = nested
+		items.CollectionFormat = "csv"
+	}
+
+	return items, true
+}
+
+// readCollectionFormat reads `collectionFormat` tag, comma-separated values apply to nested arrays,
+// e.g. `collectionFormat:"multi,pipes"` for [][]int.
+func readCollectionFormat(tag reflect.StructTag, param *ParamObj) {
+	value, ok := tag.Lookup("collectionFormat")
+	if !ok {
+		return
+	}
+
+	formats := strings.Split(value, ",")
+	param.CollectionFormat = formats[0]
+
+	for items := param.Items; items != nil && items.Type == "array" && len(formats) > 1; items = items.Items {
+		formats = formats[1:]
+		items.CollectionFormat = formats[0]
+	}
+}
+
+// flattenDeepObject renders deepObject parameter as Swagger 2.0 parameters with "filter.status"-style names.
+//
+// Map values are named with "*" key, properties of arrays of objects become arrays with collectionFormat.
+func flattenDeepObject(p ParamObj, definitions map[string]SchemaObj, collectionFormat string) []ParamObj {
+	f := deepObjectFlattener{
+		param: p, definitions: definitions, collectionFormat: collectionFormat, seen: map[string]bool{},
+	}
+	f.walk(p.Name, *p.Schema, p.Required, false)
+
+	return f.res
+}
+
+type deepObjectFlattener struct {
+	param            ParamObj
+	definitions      map[string]SchemaObj
+	collectionFormat string
+	seen             map[string]bool
+	res              []ParamObj
+}
+
+func (f *deepObjectFlattener) walk(name string, s SchemaObj, required, inArray bool) {
+	if s.Ref != "" {
+		ref := strings.TrimPrefix(s.Ref, refDefinitionPrefix)
+		if f.seen[ref] {
+			return
+		}
+
+		f.seen[ref] = true
+		defer delete(f.seen, ref)
+
+		f.walk(name, f.definitions[ref], required, inArray)
+
+		return
+	}
+
+	switch {
+	case s.Type == "object" || len(s.Properties) > 0 || s.AdditionalProperties != nil:
+		requiredProps := make(map[string]bool, len(s.Required))
+		for _, r := range s.Required {
+			requiredProps[r] = true
+		}
+
+		for _, prop := range sortedKeys(s.Properties) {
+			f.walk(name+"."+prop, s.Properties[prop], required && requiredProps[prop], inArray)
+		}
+
+		if s.AdditionalProperties != nil {
+			f.walk(name+".*", *s.AdditionalProperties, false, inArray)
+		}
+	case s.Type == "array" && s.Items != nil && f.isObject(*s.Items):
+		f.walk(name, *s.Items, required, true)
+	default:
+		p := ParamObj{Name: name, In: f.param.In, Required: required}
+		p.CommonFields = s.CommonFields
+		p.Title = ""
+
+		if s.Type == "array" && s.Items != nil {
+			p.Items, _ = paramItems(s.Items)
+			p.CollectionFormat = f.collectionFormat
+		}
+
+		if inArray && p.Type != "array" {
+			p.CommonFields = CommonFields{Type: "array", Description: s.Description}
+			p.Items = &ParamItemObj{CommonFields: s.CommonFields}
+			p.Items.Title = ""
+			p.Items.Description = ""
+			p.CollectionFormat = f.collectionFormat
+		}
+
+		f.res = append(f.res, p)
+	}
+}
+
+func (f *deepObjectFlattener) isObject(s SchemaObj) bool {
+	if s.Ref != "" {
+		s = f.definitions[strings.TrimPrefix(s.Ref, refDefinitionPrefix)]
+	}
+
+	return s.Type == "object" || len(s.Properties) > 0 || s.AdditionalProperties != nil
+}
+
+// regexDeepObjectKey matches keys of deepObject parameter without its name, e.g. "[owner][id]".
+var (
+	regexDeepObjectKey     = regexp.MustCompile(`^(\[[^\[\]]*\])+$`)
+	regexDeepObjectSegment = regexp.MustCompile(`\[([^\[\]]*)\]`)
+)
+
+// deepObjectTree collects "name[a][b]=v" query values into a tree of maps with []string leaves.
+func deepObjectTree(query url.Values, name string) (map[string]interface{}, bool) {
+	tree := map[string]interface{}{}
+	found := false
+
+	for key, values := range query {
+		if !strings.HasPrefix(key, name+"[") || !regexDeepObjectKey.MatchString(key[len(name):]) {
+			continue
+		}
+
+		var segments []string
+		for _, m := range regexDeepObjectSegment.FindAllStringSubmatch(key[len(name):], -1) {
+			segments = append(segments, m[1])
+		}
+
+		// "tags[]" is a list of values of "tags".
+		if len(segments) > 1 && segments[len(segments)-1] == "" {
+			segments = segments[:len(segments)-1]
+		}
+
+		found = true
+		node := tree
+
+		for i, seg := range segments {
+			if i == len(segments)-1 {
+				prev, _ := node[seg].([]string)
+				node[seg] = append(prev, values...)
+
+				break
+			}
+
+			child, ok := node[seg].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				node[seg] = child
+			}
+
+			node = child
+		}
+	}
+
+	return tree, found
+}
+
+// deepObjectValue converts tree of deepObject query values to JSON value according to schema.
+func deepObjectValue(s SchemaObj, definitions map[string]SchemaObj, node interface{}) (interface{}, error) {
+	for i := 0; s.Ref != "" && i < 10; i++ {
+		s = definitions[strings.TrimPrefix(s.Ref, refDefinitionPrefix)]
+	}
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if s.Type == "array" && s.Items != nil {
+			return deepObjectItems(*s.Items, definitions, n)
+		}
+
+		res := make(map[string]interface{}, len(n))
+
+		for key, child := range n {
+			ps, ok := s.Properties[key]
+			if !ok && s.AdditionalProperties != nil {
+				ps = *s.AdditionalProperties
+			}
+
+			v, err := deepObjectValue(ps, definitions, child)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+
+			res[key] = v
+		}
+
+		return res, nil
+	case []string:
+		if s.Type != "array" {
+			return decodeScalar(s.Type, n[len(n)-1])
+		}
+
+		items := SchemaObj{}
+		if s.Items != nil {
+			items = *s.Items
+		}
+
+		res := make([]interface{}, 0, len(n))
+
+		for _, v := range n {
+			item, err := deepObjectValue(items, definitions, []string{v})
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, item)
+		}
+
+		return res, nil
+	}
+
+	return nil, nil
+}
+
+// deepObjectItems converts indexed values, e.g. "items[0][id]=1&items[1][id]=2", to array.
+func deepObjectItems(items SchemaObj, definitions map[string]SchemaObj, n map[string]interface{}) (interface{}, error) {
+	indexes := make([]int, 0, len(n))
+	byIndex := make(map[int]interface{}, len(n))
+
+	for key, child := range n {
+		i, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid array index %q", key)
+		}
+
+		indexes = append(indexes, i)
+		byIndex[i] = child
+	}
+
+	sort.Ints(indexes)
+
+	res := make([]interface{}, 0, len(indexes))
+
+	for _, i := range indexes {
+		v, err := deepObjectValue(items, definitions, byIndex[i])
+		if err != nil {
+			return nil, fmt.Errorf("%d: %w", i, err)
+		}
+
+		res = append(res, v)
+	}
+
+	return res, nil
+}
+
+// setOpenAPIDeepObjects replaces JSON content of deepObject parameters with schema in OpenAPI 3.0 operation.
+func setOpenAPIDeepObjects(op *openapi3.Operation) {
+	for _, po := range op.Parameters {
+		p := po.Parameter
+		if p == nil || p.Style == nil || *p.Style != styleDeepObject {
+			continue
+		}
+
+		if mt, ok := p.Content[mimeJSON]; ok {
+			p.Schema = mt.Schema
+			p.Content = nil
+		}
+
+		p.WithExplode(true)
+	}
+}
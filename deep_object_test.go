@@ -0,0 +1,151 @@
+package swgen_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/swgen"
+)
+
+type TicketOwner struct {
+	ID   int    `json:"id" minimum:"1"`
+	Team string `json:"team"`
+}
+
+type TicketFilter struct {
+	Status string      `json:"status" enum:"open,closed"`
+	Tags   []string    `json:"tags"`
+	Owner  TicketOwner `json:"owner"`
+}
+
+type ticketLabel struct {
+	Name string `json:"name"`
+}
+
+type ticketsRequest struct {
+	Filter TicketFilter   `query:"filter" style:"deepObject" required:"true"`
+	Labels map[string]int `query:"labels" style:"deepObject"`
+	Any    []ticketLabel  `query:"any" style:"deepObject"`
+	Matrix [][]int        `query:"matrix" collectionFormat:"multi,pipes"`
+	Sort   []string       `query:"sort" collectionFormat:"csv"`
+}
+
+func TestGenerator_deepObject(t *testing.T) {
+	reflector := openapi3.Reflector{}
+
+	g := swgen.NewGenerator()
+	g.SetOAS3Proxy(&reflector)
+	g.SetPathItem(swgen.PathItemInfo{Path: "/tickets", Method: http.MethodGet, Request: new(ticketsRequest)})
+
+	data, err := g.GenDocument()
+	require.NoError(t, err)
+
+	var doc swgen.Document
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assertjson.EqualMarshal(t, []byte(`[
+	  {"type":"integer","format":"int32","minimum":1,"name":"filter.owner.id","in":"query"},
+	  {"type":"string","name":"filter.owner.team","in":"query"},
+	  {"type":"string","name":"filter.status","in":"query","enum":["open","closed"]},
+	  {
+	    "type":"array","name":"filter.tags","in":"query","items":{"type":"string"},
+	    "collectionFormat":"multi"
+	  },
+	  {"type":"integer","format":"int32","name":"labels.*","in":"query"},
+	  {
+	    "type":"array","name":"any.name","in":"query","items":{"type":"string"},
+	    "collectionFormat":"multi"
+	  },
+	  {
+	    "type":"array","name":"matrix","in":"query",
+	    "items":{"type":"array","items":{"type":"integer","format":"int32"},"collectionFormat":"pipes"},
+	    "collectionFormat":"multi"
+	  },
+	  {"type":"array","name":"sort","in":"query","items":{"type":"string"},"collectionFormat":"csv"}
+	]`), doc.Paths["/tickets"].Get.Parameters)
+
+	oas3 := g.DocumentOAS3()
+	assertjson.EqualMarshal(t, []byte(`[
+	  {
+	    "name":"filter","in":"query","required":true,"schema":{"$ref":"#/components/schemas/TicketFilter"},
+	    "style":"deepObject","explode":true
+	  },
+	  {
+	    "name":"labels","in":"query",
+	    "schema":{"type":"object","additionalProperties":{"type":"integer","format":"int32"}},
+	    "style":"deepObject","explode":true
+	  },
+	  {
+	    "name":"any","in":"query",
+	    "schema":{"type":"array","items":{"$ref":"#/components/schemas/ticketLabel"}},
+	    "style":"deepObject","explode":true
+	  },
+	  {
+	    "name":"matrix","in":"query",
+	    "schema":{"type":"array","items":{"type":"array","items":{"type":"integer","format":"int32"}}},
+	    "style":"form","explode":true
+	  },
+	  {
+	    "name":"sort","in":"query","schema":{"type":"array","items":{"type":"string"}},
+	    "style":"form","explode":false
+	  }
+	]`), oas3.Paths["/tickets"].Get.Parameters)
+
+	proxied := reflector.Spec.Paths.MapOfPathItemValues["/tickets"].MapOfOperationValues["get"]
+	assertjson.EqualMarshal(t, []byte(`{
+	  "name":"filter","in":"query","required":true,"style":"deepObject","explode":true,
+	  "schema":{"$ref":"#/components/schemas/SwgenTestTicketFilter"}
+	}`), proxied.Parameters[0].Parameter)
+
+	v := swgen.NewRequestValidator(g)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/tickets?filter[status]=open&filter[tags][]=a&filter[tags][]=b&filter[owner][id]=42"+
+			"&labels[bug]=3&any[0][name]=x&matrix=1|2&matrix=3&sort=id,name", nil)
+	assert.NoError(t, v.ValidateRequest(req))
+
+	req = httptest.NewRequest(http.MethodGet,
+		"/tickets?filter[status]=pending&filter[owner][id]=0&labels[bug]=many&matrix=1|x", nil)
+	err = v.ValidateRequest(req)
+	require.Error(t, err)
+	assertjson.EqualMarshal(t, []byte(`{
+	  "error":"invalid request",
+	  "errors":[
+	    {"in":"query","name":"filter/owner/id","message":"must be >= 1 but found 0"},
+	    {"in":"query","name":"filter/status","message":"value must be one of \"open\", \"closed\""},
+	    {"in":"query","name":"labels","message":"bug: invalid integer value \"many\""},
+	    {"in":"query","name":"matrix","message":"invalid integer value \"x\""}
+	  ]
+	}`), err)
+
+	err = v.ValidateRequest(httptest.NewRequest(http.MethodGet, "/tickets", nil))
+	require.Error(t, err)
+	assert.Equal(t, "invalid request: query filter: missing value", err.Error())
+}
+
+func TestGenerator_deepObject_invalid(t *testing.T) {
+	type (
+		headerObject struct {
+			Filter TicketFilter `header:"X-Filter" style:"deepObject"`
+		}
+		scalarObject struct {
+			Limit int `query:"limit" style:"deepObject"`
+		}
+	)
+
+	g := swgen.NewGenerator()
+
+	_, err := g.TrySetPathItem(swgen.PathItemInfo{Path: "/a", Method: http.MethodGet, Request: new(headerObject)})
+	assert.EqualError(t, err, "failed to reflect GET /a: github.com/swaggest/swgen_test.headerObject.Filter: "+
+		`failed to parse tag style:"deepObject": deepObject style is only supported in query`)
+
+	_, err = g.TrySetPathItem(swgen.PathItemInfo{Path: "/b", Method: http.MethodGet, Request: new(scalarObject)})
+	assert.EqualError(t, err, "failed to reflect GET /b: github.com/swaggest/swgen_test.scalarObject.Limit: "+
+		`failed to parse tag style:"deepObject": deepObject style requires struct, map or array of structs`)
+}
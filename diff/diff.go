@@ -0,0 +1,424 @@
+// Package diff compares Swagger documents and detects breaking changes of API contract.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/swgen"
+	"github.com/swaggest/swgen/internal/naming"
+)
+
+// Change describes a single difference between base and revised documents.
+type Change struct {
+	// Location identifies changed element, e.g. "GET /pets query limit" or "GET /pets response 200 body.name".
+	Location string `json:"location"`
+	Message  string `json:"message"`
+	Breaking bool   `json:"breaking"`
+}
+
+// String formats change.
+func (c Change) String() string {
+	kind := "non-breaking"
+	if c.Breaking {
+		kind = "breaking"
+	}
+
+	return kind + ": " + c.Location + ": " + c.Message
+}
+
+// Report lists changes ordered by location.
+type Report struct {
+	Changes []Change `json:"changes"`
+}
+
+// HasBreaking returns true if report contains breaking changes.
+func (r Report) HasBreaking() bool {
+	for _, c := range r.Changes {
+		if c.Breaking {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Breaking returns breaking changes.
+func (r Report) Breaking() []Change {
+	var res []Change
+
+	for _, c := range r.Changes {
+		if c.Breaking {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
+
+// String formats report with one change per line.
+func (r Report) String() string {
+	lines := make([]string, 0, len(r.Changes))
+
+	for _, c := range r.Changes {
+		lines = append(lines, c.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Generator compares stored swagger.json with document of Generator.
+func Generator(base []byte, g *swgen.Generator) (Report, error) {
+	revision, err := g.GenDocument()
+	if err != nil {
+		return Report{}, err
+	}
+
+	return JSON(base, revision)
+}
+
+// JSON compares two swagger.json documents.
+func JSON(base, revision []byte) (Report, error) {
+	var b, r swgen.Document
+
+	if err := json.Unmarshal(base, &b); err != nil {
+		return Report{}, fmt.Errorf("failed to decode base document: %w", err)
+	}
+
+	if err := json.Unmarshal(revision, &r); err != nil {
+		return Report{}, fmt.Errorf("failed to decode revised document: %w", err)
+	}
+
+	return Documents(b, r), nil
+}
+
+// Documents compares operations of base and revised documents.
+//
+// Changes that may fail existing clients are breaking: removed path, method, response code or property,
+// parameter that became required, narrowed enum, changed type or format.
+func Documents(base, revision swgen.Document) Report {
+	c := comparator{base: base, revision: revision}
+
+	if strings.TrimRight(base.BasePath, "/") != strings.TrimRight(revision.BasePath, "/") {
+		c.add("basePath", true, "changed from %q to %q", base.BasePath, revision.BasePath)
+	}
+
+	for _, path := range unionKeys(base.Paths, revision.Paths) {
+		basePath, inBase := base.Paths[path]
+		revPath, inRevision := revision.Paths[path]
+
+		switch {
+		case !inBase:
+			c.add(path, false, "path added")
+		case !inRevision:
+			c.add(path, true, "path removed")
+		default:
+			c.comparePathItem(path, basePath, revPath)
+		}
+	}
+
+	sort.SliceStable(c.changes, func(i, j int) bool {
+		return c.changes[i].Location < c.changes[j].Location
+	})
+
+	return Report{Changes: c.changes}
+}
+
+type comparator struct {
+	base, revision swgen.Document
+	changes        []Change
+}
+
+func (c *comparator) add(location string, breaking bool, format string, args ...interface{}) {
+	c.changes = append(c.changes, Change{
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+		Breaking: breaking,
+	})
+}
+
+func (c *comparator) comparePathItem(path string, base, revision swgen.PathItem) {
+	baseOps, revOps := base.Map(), revision.Map()
+
+	for _, method := range unionKeys(baseOps, revOps) {
+		location := method + " " + path
+		baseOp, revOp := baseOps[method], revOps[method]
+
+		switch {
+		case baseOp == nil:
+			c.add(location, false, "operation added")
+		case revOp == nil:
+			c.add(location, true, "operation removed")
+		default:
+			c.compareOperation(location, baseOp, revOp)
+		}
+	}
+}
+
+func (c *comparator) compareOperation(location string, base, revision *swgen.OperationObj) {
+	if !base.Deprecated && revision.Deprecated {
+		c.add(location, false, "operation deprecated")
+	}
+
+	baseParams, revParams := paramsByKey(base.Parameters), paramsByKey(revision.Parameters)
+
+	for _, key := range unionKeys(baseParams, revParams) {
+		baseParam, inBase := baseParams[key]
+		revParam, inRevision := revParams[key]
+		paramLocation := location + " " + key
+
+		switch {
+		case !inBase:
+			c.add(paramLocation, revParam.Required, "parameter added%s", requiredSuffix(revParam.Required))
+		case !inRevision:
+			c.add(paramLocation, false, "parameter removed")
+		default:
+			c.compareParam(paramLocation, baseParam, revParam)
+		}
+	}
+
+	codes := make(map[string]bool)
+
+	for code := range base.Responses {
+		codes[strconv.Itoa(code)] = true
+	}
+
+	for code := range revision.Responses {
+		codes[strconv.Itoa(code)] = true
+	}
+
+	for _, codeStr := range naming.SortedKeys(codes) {
+		code, _ := strconv.Atoi(codeStr)
+		baseResp, inBase := base.Responses[code]
+		revResp, inRevision := revision.Responses[code]
+		respLocation := location + " response " + codeStr
+
+		switch {
+		case !inBase:
+			c.add(respLocation, false, "response added")
+		case !inRevision:
+			c.add(respLocation, true, "response removed")
+		case baseResp.Schema != nil && revResp.Schema == nil:
+			c.add(respLocation, true, "response body removed")
+		case baseResp.Schema != nil:
+			c.compareSchema(respLocation+" body", *baseResp.Schema, *revResp.Schema, true, nil)
+		}
+	}
+}
+
+func requiredSuffix(required bool) string {
+	if required {
+		return " as required"
+	}
+
+	return ""
+}
+
+// paramsByKey maps parameters by "in name" key, body parameter is keyed by "body".
+func paramsByKey(params []swgen.ParamObj) map[string]swgen.ParamObj {
+	res := make(map[string]swgen.ParamObj, len(params))
+
+	for _, p := range params {
+		if p.In == "body" {
+			res["body"] = p
+		} else {
+			res[p.In+" "+p.Name] = p
+		}
+	}
+
+	return res
+}
+
+func (c *comparator) compareParam(location string, base, revision swgen.ParamObj) {
+	switch {
+	case !base.Required && revision.Required:
+		c.add(location, true, "parameter became required")
+	case base.Required && !revision.Required:
+		c.add(location, false, "parameter became optional")
+	}
+
+	if base.In == "body" {
+		switch {
+		case base.Schema != nil && revision.Schema != nil:
+			c.compareSchema(location, *base.Schema, *revision.Schema, false, nil)
+		case base.Schema != nil || revision.Schema != nil:
+			c.add(location, true, "schema changed")
+		}
+
+		return
+	}
+
+	c.compareFields(location, base.CommonFields, revision.CommonFields, false)
+
+	if base.CollectionFormat != revision.CollectionFormat {
+		c.add(location, true, "collection format changed from %q to %q", base.CollectionFormat, revision.CollectionFormat)
+	}
+
+	baseItems, revItems := base.Items, revision.Items
+	itemsLocation := location + "[]"
+
+	for baseItems != nil && revItems != nil {
+		c.compareFields(itemsLocation, baseItems.CommonFields, revItems.CommonFields, false)
+
+		baseItems, revItems = baseItems.Items, revItems.Items
+		itemsLocation += "[]"
+	}
+}
+
+// compareFields checks type, format and enum, response enables response semantics of enum change.
+func (c *comparator) compareFields(location string, base, revision swgen.CommonFields, response bool) {
+	if base.Type != revision.Type {
+		c.add(location, true, "type changed from %q to %q", base.Type, revision.Type)
+	} else if base.Format != revision.Format {
+		c.add(location, true, "format changed from %q to %q", base.Format, revision.Format)
+	}
+
+	switch {
+	case len(base.Enum.Enum) == 0 && len(revision.Enum.Enum) > 0:
+		c.add(location, !response, "enum added")
+	case len(base.Enum.Enum) > 0 && len(revision.Enum.Enum) == 0:
+		c.add(location, response, "enum removed")
+	default:
+		baseValues, revValues := enumValues(base.Enum.Enum), enumValues(revision.Enum.Enum)
+
+		if removed := missingKeys(baseValues, revValues); len(removed) > 0 {
+			c.add(location, true, "enum narrowed, removed %s", strings.Join(removed, ", "))
+		}
+
+		if added := missingKeys(revValues, baseValues); len(added) > 0 {
+			c.add(location, response, "enum extended, added %s", strings.Join(added, ", "))
+		}
+	}
+}
+
+// compareSchema compares schemas resolving references, response enables response semantics.
+//
+// Response schema can not remove properties or extend enums, request schema can not add required properties.
+func (c *comparator) compareSchema(location string, base, revision swgen.SchemaObj, response bool, seen map[string]bool) {
+	base, baseRef := resolve(c.base, base)
+	revision, revRef := resolve(c.revision, revision)
+
+	if baseRef != "" || revRef != "" {
+		key := baseRef + " " + revRef
+		if seen[key] {
+			return
+		}
+
+		if seen == nil {
+			seen = make(map[string]bool)
+		}
+
+		seen[key] = true
+
+		defer delete(seen, key)
+	}
+
+	c.compareFields(location, base.CommonFields, revision.CommonFields, response)
+
+	if base.Items != nil && revision.Items != nil {
+		c.compareSchema(location+"[]", *base.Items, *revision.Items, response, seen)
+	}
+
+	if base.AdditionalProperties != nil && revision.AdditionalProperties != nil {
+		c.compareSchema(location+"{}", *base.AdditionalProperties, *revision.AdditionalProperties, response, seen)
+	}
+
+	baseRequired, revRequired := stringSet(base.Required), stringSet(revision.Required)
+
+	for _, name := range unionKeys(base.Properties, revision.Properties) {
+		baseProp, inBase := base.Properties[name]
+		revProp, inRevision := revision.Properties[name]
+		propLocation := location + "." + name
+
+		switch {
+		case !inBase:
+			c.add(propLocation, !response && revRequired[name], "property added%s", requiredSuffix(revRequired[name]))
+		case !inRevision:
+			c.add(propLocation, true, "property removed")
+		default:
+			switch {
+			case !baseRequired[name] && revRequired[name]:
+				c.add(propLocation, !response, "property became required")
+			case baseRequired[name] && !revRequired[name]:
+				c.add(propLocation, response, "property became optional")
+			}
+
+			c.compareSchema(propLocation, baseProp, revProp, response, seen)
+		}
+	}
+}
+
+// resolve follows definition references, it returns resolved schema and the last reference.
+func resolve(doc swgen.Document, s swgen.SchemaObj) (swgen.SchemaObj, string) {
+	ref := ""
+
+	for i := 0; s.Ref != "" && i < 100; i++ {
+		ref = s.Ref
+
+		def, found := doc.Definitions[strings.TrimPrefix(s.Ref, "#/definitions/")]
+		if !found {
+			break
+		}
+
+		s = def
+	}
+
+	return s, ref
+}
+
+func enumValues(values []interface{}) map[string]bool {
+	res := make(map[string]bool, len(values))
+
+	for _, v := range values {
+		j, err := json.Marshal(v)
+		if err != nil {
+			j = []byte(fmt.Sprintf("%v", v))
+		}
+
+		res[string(j)] = true
+	}
+
+	return res
+}
+
+// missingKeys returns sorted keys of a that are not in b.
+func missingKeys(a, b map[string]bool) []string {
+	var res []string
+
+	for k := range a {
+		if !b[k] {
+			res = append(res, k)
+		}
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+func stringSet(values []string) map[string]bool {
+	res := make(map[string]bool, len(values))
+
+	for _, v := range values {
+		res[v] = true
+	}
+
+	return res
+}
+
+// unionKeys returns sorted keys of maps with string keys.
+func unionKeys(maps ...interface{}) []string {
+	keys := make(map[string]bool)
+
+	for _, m := range maps {
+		for _, k := range reflect.ValueOf(m).MapKeys() {
+			keys[k.String()] = true
+		}
+	}
+
+	return naming.SortedKeys(keys)
+}
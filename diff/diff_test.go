@@ -0,0 +1,121 @@
+package diff_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/swgen"
+	"github.com/swaggest/swgen/diff"
+)
+
+type petV1 struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Tag    string `json:"tag"`
+	Status string `json:"status" enum:"available,sold"`
+}
+
+type petV2 struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status" enum:"available,pending,sold"`
+	Age    int    `json:"age"`
+}
+
+type findPetsV1 struct {
+	Limit int    `query:"limit"`
+	Sort  string `query:"sort" enum:"name,age"`
+}
+
+type findPetsV2 struct {
+	Limit int    `query:"limit" required:"true"`
+	Sort  string `query:"sort" enum:"name"`
+	Page  int    `query:"page"`
+}
+
+type newPetV1 struct {
+	Name string `json:"name" required:"true"`
+}
+
+type newPetV2 struct {
+	Name  string `json:"name" required:"true"`
+	Owner string `json:"owner" required:"true"`
+}
+
+func petsGenerator(v2 bool) *swgen.Generator {
+	g := swgen.NewGenerator()
+
+	if v2 {
+		g.SetPathItem(swgen.PathItemInfo{Path: "/pets", Method: http.MethodGet, Request: new(findPetsV2), Response: []petV2{}})
+		g.SetPathItem(swgen.PathItemInfo{Path: "/pets", Method: http.MethodPost, Request: new(newPetV2), Response: new(petV2)})
+		g.SetPathItem(swgen.PathItemInfo{Path: "/owners", Method: http.MethodGet, Response: []string{}})
+
+		return g
+	}
+
+	g.SetPathItem(swgen.PathItemInfo{Path: "/pets", Method: http.MethodGet, Request: new(findPetsV1), Response: []petV1{}}).
+		Responses[http.StatusNotFound] = swgen.ResponseObj{Description: "Not Found"}
+	g.SetPathItem(swgen.PathItemInfo{Path: "/pets", Method: http.MethodPost, Request: new(newPetV1), Response: new(petV1)})
+	g.SetPathItem(swgen.PathItemInfo{Path: "/pets/{id}", Method: http.MethodDelete, Request: new(struct {
+		ID int64 `path:"id"`
+	})})
+
+	return g
+}
+
+func TestGenerator(t *testing.T) {
+	base, err := petsGenerator(false).GenDocument()
+	require.NoError(t, err)
+
+	report, err := diff.Generator(base, petsGenerator(true))
+	require.NoError(t, err)
+
+	assert.True(t, report.HasBreaking())
+	assert.Len(t, report.Breaking(), 11)
+	assert.Equal(t, `non-breaking: /owners: path added
+breaking: /pets/{id}: path removed
+breaking: GET /pets query limit: parameter became required
+non-breaking: GET /pets query page: parameter added
+breaking: GET /pets query sort: enum narrowed, removed "age"
+non-breaking: GET /pets response 200 body[].age: property added
+breaking: GET /pets response 200 body[].id: type changed from "integer" to "string"
+breaking: GET /pets response 200 body[].status: enum extended, added "pending"
+breaking: GET /pets response 200 body[].tag: property removed
+breaking: GET /pets response 404: response removed
+breaking: POST /pets body.owner: property added as required
+non-breaking: POST /pets response 200 body.age: property added
+breaking: POST /pets response 200 body.id: type changed from "integer" to "string"
+breaking: POST /pets response 200 body.status: enum extended, added "pending"
+breaking: POST /pets response 200 body.tag: property removed`, report.String())
+
+	same, err := diff.Generator(base, petsGenerator(false))
+	require.NoError(t, err)
+	assert.Empty(t, same.Changes)
+	assert.False(t, same.HasBreaking())
+}
+
+func TestDocuments_reverse(t *testing.T) {
+	base, err := petsGenerator(true).GenDocument()
+	require.NoError(t, err)
+
+	revision, err := petsGenerator(false).GenDocument()
+	require.NoError(t, err)
+
+	report, err := diff.JSON(base, revision)
+	require.NoError(t, err)
+
+	for _, c := range report.Changes {
+		if c.Location == "GET /pets query limit" {
+			assert.Equal(t, diff.Change{Location: c.Location, Message: "parameter became optional"}, c)
+		}
+
+		if c.Location == "GET /pets query page" {
+			assert.Equal(t, diff.Change{Location: c.Location, Message: "parameter removed"}, c)
+		}
+	}
+
+	_, err = diff.JSON([]byte("{"), revision)
+	assert.EqualError(t, err, "failed to decode base document: unexpected end of JSON input")
+}
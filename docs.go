@@ -0,0 +1,94 @@
+package swgen
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/swaggest/swgui"
+	v3 "github.com/swaggest/swgui/v3"
+)
+
+// DocsConfig controls documentation UI served by DocsHandler.
+type DocsConfig struct {
+	// Title of documentation page, current document title is used by default.
+	Title string
+
+	// DocExpansion controls default expansion of operations: "list" (default), "full" or "none".
+	DocExpansion string
+
+	// Host is used in served document if Generator has no host, request host is used by default.
+	Host string
+}
+
+// DocsHandler creates HTTP handler of Swagger UI with assets embedded into the binary.
+//
+// Handler should be mounted at prefix, it serves UI at prefix + "/", document at prefix + "/swagger.json"
+// and prefix + "/swagger.yaml".
+func (g *Generator) DocsHandler(prefix string, cfg ...DocsConfig) http.Handler {
+	var c DocsConfig
+	if len(cfg) != 0 {
+		c = cfg[0]
+	}
+
+	if c.DocExpansion == "" {
+		c.DocExpansion = "list"
+	}
+
+	prefix = strings.TrimRight(prefix, "/")
+	jsonPath := prefix + "/swagger.json"
+	yamlPath := prefix + "/swagger.yaml"
+
+	var (
+		mu      sync.Mutex
+		ui      http.Handler
+		uiTitle string
+	)
+
+	// uiHandler returns UI handler with current title, it is created again when document title changes.
+	uiHandler := func() http.Handler {
+		title := c.Title
+		if title == "" {
+			g.mu.Lock()
+			title = g.doc.Info.Title
+			g.mu.Unlock()
+		}
+
+		if title == "" {
+			title = "API Documentation"
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if ui == nil || uiTitle != title {
+			uiTitle = title
+			ui = v3.NewHandlerWithConfig(swgui.Config{
+				Title:       title,
+				SwaggerJSON: jsonPath,
+				BasePath:    prefix + "/",
+				SettingsUI: map[string]string{
+					"docExpansion": strconv.Quote(c.DocExpansion),
+				},
+			})
+		}
+
+		return ui
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != jsonPath && r.URL.Path != yamlPath {
+			uiHandler().ServeHTTP(w, r)
+
+			return
+		}
+
+		host := r.URL.Host
+		if c.Host != "" {
+			host = c.Host
+		}
+
+		g.serveDocument(w, r, &host)
+	})
+}
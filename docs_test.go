@@ -0,0 +1,59 @@
+package swgen_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/swgen"
+)
+
+func TestGenerator_DocsHandler(t *testing.T) {
+	g := swgen.NewGenerator()
+	g.SetInfo("Pets", "", "", "1.0")
+
+	h := g.DocsHandler("/docs/", swgen.DocsConfig{
+		DocExpansion: "none",
+		Host:         "api.example.com",
+	})
+
+	get := func(uri string) *httptest.ResponseRecorder {
+		rw := httptest.NewRecorder()
+		h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, uri, nil))
+
+		return rw
+	}
+
+	rw := get("/docs/")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "text/html", rw.Header().Get("Content-Type"))
+	assert.Contains(t, rw.Body.String(), "<title>Pets - Swagger UI</title>")
+	assert.Contains(t, rw.Body.String(), `"swaggerJsonUrl":"/docs/swagger.json"`)
+	assert.Contains(t, rw.Body.String(), `docExpansion: "none"`)
+	assert.NotContains(t, rw.Body.String(), "cdn")
+
+	g.SetInfo("Pet Store", "", "", "1.0")
+
+	rw = get("/docs/")
+	assert.Contains(t, rw.Body.String(), "<title>Pet Store - Swagger UI</title>")
+
+	rw = get("/docs/swagger-ui-bundle.js")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.NotEmpty(t, rw.Body.Bytes())
+
+	rw = get("/docs/swagger.json")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "application/json", rw.Header().Get("Content-Type"))
+	assertjson.Equal(t, []byte(`{
+	  "swagger":"2.0",
+	  "info":{"title":"Pet Store","description":"","termsOfService":"","contact":{"name":""},"license":{"name":""},"version":"1.0"},
+	  "host":"api.example.com","basePath":"/","schemes":["http","https"],"paths":{}
+	}`), rw.Body.Bytes(), rw.Body.String())
+
+	rw = get("/docs/swagger.yaml")
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "application/yaml", rw.Header().Get("Content-Type"))
+	assert.Contains(t, rw.Body.String(), "host: api.example.com\n")
+}
@@ -0,0 +1,214 @@
+package swgen
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// maxCachedDocuments limits number of cached renderings (per format and request host).
+const maxCachedDocuments = 16
+
+// renderedDocument is a cached document rendering.
+type renderedDocument struct {
+	data []byte
+	etag string
+
+	gzipOnce sync.Once
+	gzipped  []byte
+}
+
+func newRenderedDocument(data []byte) *renderedDocument {
+	sum := sha256.Sum256(data)
+
+	return &renderedDocument{
+		data: data,
+		etag: `"` + hex.EncodeToString(sum[:16]) + `"`,
+	}
+}
+
+// gzipData returns gzip-compressed document, compression is done once.
+func (rd *renderedDocument) gzipData() []byte {
+	rd.gzipOnce.Do(func() {
+		buf := bytes.NewBuffer(nil)
+		zw := gzip.NewWriter(buf)
+
+		if _, err := zw.Write(rd.data); err != nil {
+			return
+		}
+
+		if err := zw.Close(); err != nil {
+			return
+		}
+
+		rd.gzipped = buf.Bytes()
+	})
+
+	return rd.gzipped
+}
+
+// gzipETag returns entity tag of gzip-encoded document.
+func (rd *renderedDocument) gzipETag() string {
+	return strings.TrimSuffix(rd.etag, `"`) + `-gzip"`
+}
+
+// ResetDocumentCache drops cached renderings of document served by ServeHTTP.
+//
+// Generator mutators reset cache automatically, this method is only needed after changing
+// objects returned by Generator, e.g. *OperationObj of SetPathItem.
+func (g *Generator) ResetDocumentCache() {
+	g.cacheMu.Lock()
+	defer g.cacheMu.Unlock()
+
+	g.cacheVersion++
+	g.cache = nil
+}
+
+// renderDocument returns cached document rendering or renders and caches a new one.
+func (g *Generator) renderDocument(host *string, yaml bool) (*renderedDocument, error) {
+	key := "json "
+	if yaml {
+		key = "yaml "
+	}
+
+	if host != nil {
+		key += *host
+	}
+
+	g.cacheMu.Lock()
+	rd, found := g.cache[key]
+	version := g.cacheVersion
+	g.cacheMu.Unlock()
+
+	if found {
+		return rd, nil
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+
+	if yaml {
+		data, err = g.genDocumentYAML(host)
+	} else {
+		data, err = g.genDocument(host)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	rd = newRenderedDocument(data)
+
+	g.cacheMu.Lock()
+	defer g.cacheMu.Unlock()
+
+	// Skip caching if generator was changed during rendering.
+	if version != g.cacheVersion {
+		return rd, nil
+	}
+
+	if g.cache == nil || len(g.cache) >= maxCachedDocuments {
+		g.cache = make(map[string]*renderedDocument)
+	}
+
+	g.cache[key] = rd
+
+	return rd, nil
+}
+
+// serveDocument writes document with host fallback for Generator without host.
+//
+// Cached rendering is used, client receives 304 Not Modified if its copy is up to date.
+func (g *Generator) serveDocument(w http.ResponseWriter, r *http.Request, host *string) {
+	yaml := acceptsYAML(r)
+
+	rd, err := g.renderDocument(host, yaml)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	g.writeCORSHeaders(w)
+
+	contentType := "application/json"
+	if yaml {
+		contentType = mimeYAML
+	}
+
+	data, etag := rd.data, rd.etag
+	useGzip := acceptsGzip(r)
+
+	if useGzip {
+		data, etag = rd.gzipData(), rd.gzipETag()
+		useGzip = data != nil
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", etag)
+	w.Header().Add("Vary", "Accept, Accept-Encoding")
+
+	if etagMatches(r.Header.Get("If-None-Match"), rd.etag, rd.gzipETag()) {
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	if useGzip {
+		w.Header().Set("Content-Encoding", "gzip")
+	}
+
+	_, err = w.Write(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// acceptsGzip checks if request allows gzip content encoding.
+func acceptsGzip(r *http.Request) bool {
+	for _, enc := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		parts := strings.Split(enc, ";")
+		if strings.TrimSpace(parts[0]) != "gzip" {
+			continue
+		}
+
+		for _, p := range parts[1:] {
+			if q := strings.TrimSpace(p); q == "q=0" || q == "q=0.0" || q == "q=0.00" || q == "q=0.000" {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// etagMatches checks If-None-Match header value against entity tags.
+func etagMatches(ifNoneMatch string, etags ...string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+
+		if tag == "*" {
+			return true
+		}
+
+		for _, etag := range etags {
+			if tag == etag {
+				return true
+			}
+		}
+	}
+
+	return false
+}
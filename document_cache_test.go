@@ -0,0 +1,86 @@
+package swgen_test
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/swgen"
+)
+
+func TestGenerator_ServeHTTP_cache(t *testing.T) {
+	g := swgen.NewGenerator()
+	g.SetHost("api.example.com")
+	g.SetInfo("Title", "", "", "1.0")
+
+	get := func(header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/docs/swagger.json", nil)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+
+		rw := httptest.NewRecorder()
+		g.ServeHTTP(rw, req)
+
+		return rw
+	}
+
+	rw := get(nil)
+	assert.Equal(t, http.StatusOK, rw.Code)
+
+	etag := rw.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	body := rw.Body.String()
+	assert.Contains(t, body, `"title":"Title"`)
+
+	rw = get(map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusNotModified, rw.Code)
+	assert.Empty(t, rw.Body.String())
+	assert.Equal(t, etag, rw.Header().Get("ETag"))
+
+	rw = get(map[string]string{"Accept-Encoding": "gzip, deflate"})
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "gzip", rw.Header().Get("Content-Encoding"))
+	assert.NotEqual(t, etag, rw.Header().Get("ETag"))
+
+	zr, err := gzip.NewReader(rw.Body)
+	require.NoError(t, err)
+	unzipped, err := ioutil.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(unzipped))
+
+	rw = get(map[string]string{"Accept-Encoding": "gzip", "If-None-Match": rw.Header().Get("ETag")})
+	assert.Equal(t, http.StatusNotModified, rw.Code)
+
+	// Mutators invalidate cache.
+	g.SetInfo("New Title", "", "", "1.0")
+
+	rw = get(map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.NotEqual(t, etag, rw.Header().Get("ETag"))
+	assert.Contains(t, rw.Body.String(), `"title":"New Title"`)
+	etag = rw.Header().Get("ETag")
+
+	g.SetPathItem(swgen.PathItemInfo{Method: http.MethodGet, Path: "/items"})
+
+	rw = get(map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Contains(t, rw.Body.String(), `"/items"`)
+	etag = rw.Header().Get("ETag")
+
+	// Changes of returned objects require explicit reset.
+	op := g.SetPathItem(swgen.PathItemInfo{Method: http.MethodPost, Path: "/items"})
+	rw = get(nil)
+	etag2 := rw.Header().Get("ETag")
+	assert.NotEqual(t, etag, etag2)
+
+	op.AddExtendedField("x-foo", "bar")
+	assert.NotContains(t, get(nil).Body.String(), `"x-foo"`)
+
+	g.ResetDocumentCache()
+	assert.Contains(t, get(nil).Body.String(), `"x-foo":"bar"`)
+}
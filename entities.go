@@ -29,6 +29,20 @@ func (s Document) MarshalJSON() ([]byte, error) {
 	return s.marshalJSONWithStruct(i(s))
 }
 
+// UnmarshalJSON unmarshal Document with vendor extensions collected to additionalData.
+func (s *Document) UnmarshalJSON(data []byte) error {
+	type i Document
+
+	v := i{}
+	if err := v.unmarshalJSONWithStruct(data, &v); err != nil {
+		return err
+	}
+
+	*s = Document(v)
+
+	return nil
+}
+
 // InfoObj provides metadata about the API.
 type InfoObj struct {
 	Title          string     `json:"title"` // The title of the application
@@ -122,6 +136,70 @@ func (pi PathItem) Map() map[string]*OperationObj {
 	return result
 }
 
+// setOperation sets operation for given method.
+func (pi *PathItem) setOperation(method string, op *OperationObj) {
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		pi.Get = op
+	case http.MethodPost:
+		pi.Post = op
+	case http.MethodPut:
+		pi.Put = op
+	case http.MethodDelete:
+		pi.Delete = op
+	case http.MethodOptions:
+		pi.Options = op
+	case http.MethodHead:
+		pi.Head = op
+	case http.MethodPatch:
+		pi.Patch = op
+	}
+}
+
+// UnmarshalJSON unmarshals PathItem and applies path level parameters to all operations.
+func (pi *PathItem) UnmarshalJSON(data []byte) error {
+	type i PathItem
+
+	aux := struct {
+		i
+		Params []ParamObj `json:"parameters,omitempty"`
+	}{}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	*pi = PathItem(aux.i)
+
+	if len(aux.Params) == 0 {
+		return nil
+	}
+
+	for _, op := range pi.Map() {
+		params := make([]ParamObj, 0, len(aux.Params)+len(op.Parameters))
+
+		for _, p := range aux.Params {
+			overridden := false
+
+			for _, op := range op.Parameters {
+				if op.Name == p.Name && op.In == p.In {
+					overridden = true
+
+					break
+				}
+			}
+
+			if !overridden {
+				params = append(params, p)
+			}
+		}
+
+		op.Parameters = append(params, op.Parameters...)
+	}
+
+	return nil
+}
+
 type securityType string
 
 const (
@@ -175,6 +253,8 @@ type SecurityDef struct {
 	BearerFormat string `json:"-"`
 
 	Description string `json:"description,omitempty"`
+
+	isBearer bool // bearer token is described as apiKey in Swagger 2.0
 }
 
 // PathItemInfo some basic information of a path item and operation object.
@@ -192,10 +272,32 @@ type PathItemInfo struct {
 	// Output holds a sample of successful response, e.g. new(MyResponse).
 	Response interface{}
 
+	// RequestExamples holds named examples of request body, e.g. map[string]interface{}{"default": MyRequest{...}}.
+	// Examples are marshaled with encoding/json and validated against schema of request body.
+	RequestExamples map[string]interface{}
+
+	// ResponseExamples holds examples of response bodies by HTTP status code.
+	// Examples are marshaled with encoding/json and validated against schema of response.
+	ResponseExamples map[int]interface{}
+
 	// MIME types of input and output
 	Produces []string
 	Consumes []string
 
+	// RequestContent holds samples of request body by MIME type, e.g.
+	// map[string]interface{}{"application/x-www-form-urlencoded": new(OrderForm)}, nil sample stands for binary body.
+	// JSON body of Request is used for "application/json" unless overridden, Request still defines parameters.
+	// Swagger 2.0 body is reflected from JSON sample, form samples become "formData" parameters if there is no body.
+	// Definitions of other samples are used by OpenAPI 3.0, Swagger 2.0 lists them without references.
+	RequestContent map[string]interface{}
+
+	// ResponseContent holds samples of successful response body by MIME type, e.g.
+	// map[string]interface{}{"text/csv": "", "application/pdf": nil}, nil sample stands for binary body.
+	// Response is used for "application/json" unless overridden.
+	// Swagger 2.0 schema is reflected from JSON sample, or from the first sample if there is no JSON.
+	// Definitions of other samples are used by OpenAPI 3.0, Swagger 2.0 lists them without references.
+	ResponseContent map[string]interface{}
+
 	Security       []string            // Names of security definitions.
 	SecurityOAuth2 map[string][]string // Map of names of security definitions to required scopes.
 
@@ -410,6 +512,8 @@ type OperationObj struct {
 	Security    []map[string][]string `json:"security,omitempty"`
 	Deprecated  bool                  `json:"deprecated,omitempty"`
 	additionalData
+
+	requestContent map[string]*SchemaObj // schemas of request bodies by MIME type that differ from body parameter
 }
 
 // MarshalJSON marshal OperationObj with additionalData inlined.
@@ -419,6 +523,20 @@ func (o OperationObj) MarshalJSON() ([]byte, error) {
 	return o.marshalJSONWithStruct(i(o))
 }
 
+// UnmarshalJSON unmarshal OperationObj with vendor extensions collected to additionalData.
+func (o *OperationObj) UnmarshalJSON(data []byte) error {
+	type i OperationObj
+
+	v := i{}
+	if err := v.unmarshalJSONWithStruct(data, &v); err != nil {
+		return err
+	}
+
+	*o = OperationObj(v)
+
+	return nil
+}
+
 // ParamObj describes a single operation parameter, see http://swagger.io/specification/#parameterObject.
 type ParamObj struct {
 	CommonFields
@@ -437,6 +555,9 @@ type ParamObj struct {
 
 	Required bool `json:"required,omitempty"`
 	additionalData
+
+	partSchema *SchemaObj // schema of structure sent as a part of multipart form, Type is "string" then
+	style      string     // OpenAPI 3.0 serialization style, e.g. "deepObject" with object Schema
 }
 
 func jsonRecode(v interface{}) (map[string]interface{}, error) {
@@ -472,6 +593,34 @@ func (o SchemaObj) MarshalJSON() ([]byte, error) {
 	return o.marshalJSONWithStruct(i(o))
 }
 
+// UnmarshalJSON unmarshal ParamObj with vendor extensions collected to additionalData.
+func (o *ParamObj) UnmarshalJSON(data []byte) error {
+	type i ParamObj
+
+	v := i{}
+	if err := v.unmarshalJSONWithStruct(data, &v); err != nil {
+		return err
+	}
+
+	*o = ParamObj(v)
+
+	return nil
+}
+
+// UnmarshalJSON unmarshal SchemaObj with vendor extensions collected to additionalData.
+func (o *SchemaObj) UnmarshalJSON(data []byte) error {
+	type i SchemaObj
+
+	v := i{}
+	if err := v.unmarshalJSONWithStruct(data, &v); err != nil {
+		return err
+	}
+
+	*o = SchemaObj(v)
+
+	return nil
+}
+
 // ParamItemObj describes an property object, in param object or property of definition, see http://swagger.io/specification/#itemsObject.
 type ParamItemObj struct {
 	CommonFields
@@ -484,11 +633,20 @@ type Responses map[int]ResponseObj
 
 // ResponseObj describes a single response from an API Operation.
 type ResponseObj struct {
-	Ref         string      `json:"$ref,omitempty"`
-	Description string      `json:"description,omitempty"`
-	Schema      *SchemaObj  `json:"schema,omitempty"`
-	Headers     interface{} `json:"headers,omitempty"`
-	Examples    interface{} `json:"examples,omitempty"`
+	Ref         string               `json:"$ref,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Schema      *SchemaObj           `json:"schema,omitempty"`
+	Headers     map[string]HeaderObj `json:"headers,omitempty"`
+	Examples    interface{}          `json:"examples,omitempty"`
+
+	content map[string]*SchemaObj // schemas of bodies by MIME type that differ from Schema
+}
+
+// HeaderObj describes a response header, see http://swagger.io/specification/#headerObject.
+type HeaderObj struct {
+	CommonFields
+	Items            *ParamItemObj `json:"items,omitempty"`            // Required if type is "array"
+	CollectionFormat string        `json:"collectionFormat,omitempty"` // "multi" is not valid for headers
 }
 
 // SchemaObj describes a schema for json format.
@@ -499,6 +657,11 @@ type SchemaObj struct {
 	AdditionalProperties *SchemaObj           `json:"additionalProperties,omitempty"` // if type is object (map[])
 	Required             []string             `json:"required,omitempty"`
 	Properties           map[string]SchemaObj `json:"properties,omitempty"` // if type is object
+	AllOf                []SchemaObj          `json:"allOf,omitempty"`
+	OneOf                []SchemaObj          `json:"x-oneOf,omitempty"` // rendered as oneOf in OpenAPI 3.0 and JSON Schema
+	AnyOf                []SchemaObj          `json:"x-anyOf,omitempty"` // rendered as anyOf in OpenAPI 3.0 and JSON Schema
+	Discriminator        string               `json:"discriminator,omitempty"`
+	DiscriminatorMapping map[string]string    `json:"x-discriminator-mapping,omitempty"` // references by discriminator value
 	Example              interface{}          `json:"example,omitempty"`
 	Nullable             bool                 `json:"x-nullable,omitempty"`
 	TypeName             string               `json:"-"` // for internal using, passing typeName
@@ -579,6 +742,13 @@ func (ad *additionalData) AddExtendedField(name string, value interface{}) {
 	ad.data[name] = value
 }
 
+// ExtendedField returns value of field from additional data map.
+func (ad additionalData) ExtendedField(name string) (interface{}, bool) {
+	value, ok := ad.data[name]
+
+	return value, ok
+}
+
 func (ad additionalData) marshalJSONWithStruct(i interface{}) ([]byte, error) {
 	result, err := json.Marshal(i)
 	if err != nil {
@@ -603,3 +773,65 @@ func (ad additionalData) marshalJSONWithStruct(i interface{}) ([]byte, error) {
 
 	return result, nil
 }
+
+// unmarshalJSONWithStruct decodes data into i and collects unknown vendor extensions ("x-" keys).
+func (ad *additionalData) unmarshalJSONWithStruct(data []byte, i interface{}) error {
+	if err := json.Unmarshal(data, i); err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var known map[string]bool
+
+	for name, value := range raw {
+		if !strings.HasPrefix(name, "x-") {
+			continue
+		}
+
+		if known == nil {
+			known = jsonFieldNames(reflect.TypeOf(i).Elem())
+		}
+
+		if known[name] {
+			continue
+		}
+
+		var v interface{}
+		if err := json.Unmarshal(value, &v); err != nil {
+			return err
+		}
+
+		ad.AddExtendedField(name, v)
+	}
+
+	return nil
+}
+
+// jsonFieldNames returns names of JSON properties of a struct type including embedded structs.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+
+		if name == "" && f.Anonymous && f.Type.Kind() == reflect.Struct {
+			for n := range jsonFieldNames(f.Type) {
+				names[n] = true
+			}
+
+			continue
+		}
+
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+
+	return names
+}
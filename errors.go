@@ -0,0 +1,124 @@
+package swgen
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	errNonEmptyInterface     = errors.New("non-empty interface is not supported")
+	errUnsupportedKind       = errors.New("type kind is not supported")
+	errUnsupportedParamType  = errors.New("unsupported parameter type")
+	errUnsupportedParamItems = errors.New("unsupported array of struct in parameter, use style:\"deepObject\" tag")
+	errConflictingFileParam  = errors.New("conflicting file parameters with the same name")
+)
+
+// FieldError describes a single problem found while reflecting a Go type.
+type FieldError struct {
+	GoType string // Go type that declares the field, e.g. "github.com/acme/api.Request".
+	Field  string // Field path within GoType, e.g. "Filter.Status", empty for type level problems.
+	Tag    string // Name of struct tag that failed, empty if problem is not related to a tag.
+	Value  string // Offending value: tag value or Go type name.
+	Err    error
+}
+
+// Error implements error.
+func (e FieldError) Error() string {
+	var loc string
+
+	switch {
+	case e.GoType != "" && e.Field != "":
+		loc = e.GoType + "." + e.Field
+	case e.GoType != "":
+		loc = e.GoType
+	default:
+		loc = e.Field
+	}
+
+	msg := e.Err.Error()
+
+	if e.Tag != "" {
+		msg = fmt.Sprintf("failed to parse tag %s:%q: %s", e.Tag, e.Value, msg)
+	} else if e.Value != "" {
+		msg = e.Value + ": " + msg
+	}
+
+	if loc == "" {
+		return msg
+	}
+
+	return loc + ": " + msg
+}
+
+// Unwrap returns underlying error.
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ReflectError holds all problems found while registering an operation or parsing a definition.
+type ReflectError struct {
+	Method string // Empty for definitions.
+	Path   string // Empty for definitions.
+	Errors []FieldError
+}
+
+// Error implements error.
+func (e ReflectError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, fe := range e.Errors {
+		msgs = append(msgs, fe.Error())
+	}
+
+	subject := "definition"
+	if e.Method != "" || e.Path != "" {
+		subject = e.Method + " " + e.Path
+	}
+
+	return fmt.Sprintf("failed to reflect %s: %s", subject, strings.Join(msgs, "; "))
+}
+
+// reflectScope identifies the struct field being reflected to enrich errors.
+type reflectScope struct {
+	goType string
+	field  string
+}
+
+func (g *Generator) enterScope(goType, field string) (restore func()) {
+	prev := g.scope
+	g.scope = reflectScope{goType: goType, field: field}
+
+	return func() {
+		g.scope = prev
+	}
+}
+
+// addReflectError records a problem instead of failing immediately so that all issues can be reported at once.
+func (g *Generator) addReflectError(err error) {
+	if err == nil {
+		return
+	}
+
+	var fe FieldError
+
+	if e, ok := err.(FieldError); ok {
+		fe = e
+	} else {
+		fe.Err = err
+	}
+
+	if fe.GoType == "" && fe.Field == "" {
+		fe.GoType = g.scope.goType
+		fe.Field = g.scope.field
+	}
+
+	g.reflectErrors = append(g.reflectErrors, fe)
+}
+
+// takeReflectErrors returns and clears recorded problems.
+func (g *Generator) takeReflectErrors() []FieldError {
+	errs := g.reflectErrors
+	g.reflectErrors = nil
+
+	return errs
+}
@@ -0,0 +1,399 @@
+package swgen
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp/syntax"
+	"strings"
+	"time"
+)
+
+// exampleBaseTime is a start of range of synthesized date-time values.
+var exampleBaseTime = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// SynthesizeExamples enables examples synthesized from schemas for responses without explicit examples.
+//
+// Synthesized examples are added to ResponseObj.Examples when path item is registered.
+func (g *Generator) SynthesizeExamples(enabled bool) *Generator {
+	g.mu.Lock()
+	g.synthesizeExamples = enabled
+	g.ResetDocumentCache()
+	g.mu.Unlock()
+
+	return g
+}
+
+// SetExampleSeed sets seed of random values in synthesized examples, default is 0.
+func (g *Generator) SetExampleSeed(seed int64) *Generator {
+	g.mu.Lock()
+	g.exampleSeed = seed
+	g.ResetDocumentCache()
+	g.mu.Unlock()
+
+	return g
+}
+
+// ExampleFor synthesizes example value for schema, references are resolved with registered definitions.
+//
+// Example, default and enum values are used if available. Otherwise value is generated to match type, format
+// (date-time, date, email, uuid, ipv4, uri), numeric bounds, length and pattern. Results are reproducible
+// for the same schema and seed, see SetExampleSeed.
+func (g *Generator) ExampleFor(s SchemaObj) interface{} {
+	g.mu.Lock()
+	seed := g.exampleSeed
+	definitions := g.exampleDefinitionsSnapshot()
+	g.mu.Unlock()
+
+	return newExampler(seed, definitions).value(s)
+}
+
+// exampleDefinitionsSnapshot returns definitions for synthesized examples, they are only rebuilt after
+// Generator changes, so that serving of mock responses does not render definitions on every request.
+func (g *Generator) exampleDefinitionsSnapshot() map[string]SchemaObj {
+	g.cacheMu.Lock()
+	version := g.cacheVersion
+	g.cacheMu.Unlock()
+
+	if g.exampleDefinitions == nil || g.exampleVersion != version {
+		g.exampleDefinitions = g.definitions.GenDefinitions()
+		g.exampleVersion = version
+	}
+
+	return g.exampleDefinitions
+}
+
+func newExampler(seed int64, definitions map[string]SchemaObj) *exampler {
+	return &exampler{
+		rnd:         rand.New(rand.NewSource(seed)), //nolint:gosec // Examples do not need secure random.
+		definitions: definitions,
+		seen:        make(map[string]bool),
+	}
+}
+
+type exampler struct {
+	rnd         *rand.Rand
+	definitions map[string]SchemaObj
+	seen        map[string]bool // references being synthesized, to break recursion
+}
+
+func (e *exampler) value(s SchemaObj) interface{} {
+	switch {
+	case s.Example != nil:
+		return s.Example
+	case s.Default != nil:
+		return s.Default
+	case len(s.Enum.Enum) > 0:
+		return s.Enum.Enum[e.rnd.Intn(len(s.Enum.Enum))]
+	case s.Ref != "":
+		return e.ref(s.Ref)
+	case len(s.OneOf) > 0 || len(s.AnyOf) > 0 || len(s.AllOf) > 0:
+		return e.composition(s)
+	}
+
+	switch s.Type {
+	case "object":
+		return e.object(s, nil)
+	case "array":
+		return e.array(s)
+	case "string":
+		return e.string(s)
+	case "integer":
+		return int64(e.number(s, true))
+	case "number":
+		return e.number(s, false)
+	case "boolean":
+		return e.rnd.Intn(2) == 1
+	}
+
+	return nil
+}
+
+func (e *exampler) ref(ref string) interface{} {
+	def, found := e.definitions[strings.TrimPrefix(ref, refDefinitionPrefix)]
+	if !found || e.seen[ref] {
+		return nil
+	}
+
+	e.seen[ref] = true
+	defer delete(e.seen, ref)
+
+	return e.value(def)
+}
+
+// composition synthesizes first variant of oneOf and anyOf or merges all variants of allOf.
+func (e *exampler) composition(s SchemaObj) interface{} {
+	var variant SchemaObj
+
+	switch {
+	case len(s.AllOf) > 0:
+		merged := make(map[string]interface{})
+
+		for _, v := range s.AllOf {
+			if m, ok := e.value(v).(map[string]interface{}); ok {
+				for k, val := range m {
+					merged[k] = val
+				}
+			}
+		}
+
+		return e.object(s, merged)
+	case len(s.OneOf) > 0:
+		variant = s.OneOf[0]
+	default:
+		variant = s.AnyOf[0]
+	}
+
+	res := e.value(variant)
+
+	m, ok := res.(map[string]interface{})
+	if !ok {
+		return res
+	}
+
+	m = e.object(s, m)
+
+	// Discriminator value has to identify synthesized variant.
+	if s.Discriminator != "" {
+		for _, value := range sortedKeys(s.DiscriminatorMapping) {
+			if s.DiscriminatorMapping[value] == variant.Ref {
+				m[s.Discriminator] = value
+
+				break
+			}
+		}
+	}
+
+	return m
+}
+
+// object adds synthesized properties of schema to values, properties that are already set are kept.
+func (e *exampler) object(s SchemaObj, values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		values = make(map[string]interface{}, len(s.Properties))
+	}
+
+	for _, name := range sortedKeys(s.Properties) {
+		if _, ok := values[name]; ok {
+			continue
+		}
+
+		if v := e.value(s.Properties[name]); v != nil {
+			values[name] = v
+		}
+	}
+
+	if s.AdditionalProperties != nil && len(values) == 0 {
+		if v := e.value(*s.AdditionalProperties); v != nil {
+			values["key"] = v
+		}
+	}
+
+	return values
+}
+
+func (e *exampler) array(s SchemaObj) []interface{} {
+	if s.Items == nil {
+		return []interface{}{}
+	}
+
+	n := int64(1)
+	if s.MinItems != nil && *s.MinItems > n {
+		n = *s.MinItems
+	}
+
+	if s.MaxItems != nil && *s.MaxItems < n {
+		n = *s.MaxItems
+	}
+
+	res := make([]interface{}, 0, n)
+
+	for i := int64(0); i < n; i++ {
+		v := e.value(*s.Items)
+		if v == nil {
+			break
+		}
+
+		res = append(res, v)
+	}
+
+	return res
+}
+
+func (e *exampler) string(s SchemaObj) string {
+	switch s.Format {
+	case "date-time":
+		return exampleBaseTime.Add(time.Duration(e.rnd.Int63n(365*24*3600)) * time.Second).Format(time.RFC3339)
+	case "date":
+		return exampleBaseTime.AddDate(0, 0, e.rnd.Intn(365)).Format("2006-01-02")
+	case "email":
+		return e.word(6) + "@example.com"
+	case "uuid":
+		b := make([]byte, 16)
+		e.rnd.Read(b)
+		b[6] = b[6]&0x0f | 0x40 // version 4
+		b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	case "ipv4":
+		return fmt.Sprintf("%d.%d.%d.%d", 1+e.rnd.Intn(254), e.rnd.Intn(256), e.rnd.Intn(256), 1+e.rnd.Intn(254))
+	case "uri", "url":
+		return "https://example.com/" + e.word(6)
+	}
+
+	if s.Pattern != "" {
+		if re, err := syntax.Parse(s.Pattern, syntax.Perl); err == nil {
+			var sb strings.Builder
+
+			e.pattern(&sb, re.Simplify())
+
+			return sb.String()
+		}
+	}
+
+	n := int64(8)
+	if s.MinLength != nil && *s.MinLength > n {
+		n = *s.MinLength
+	}
+
+	if s.MaxLength != nil && *s.MaxLength < n {
+		n = *s.MaxLength
+	}
+
+	return e.word(int(n))
+}
+
+func (e *exampler) word(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('a' + e.rnd.Intn(26))
+	}
+
+	return string(b)
+}
+
+// maxPatternRepeat limits unbounded repetitions in strings synthesized from patterns.
+const maxPatternRepeat = 3
+
+// pattern writes a random string matching regular expression.
+func (e *exampler) pattern(sb *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		sb.WriteString(string(re.Rune))
+	case syntax.OpCharClass:
+		sb.WriteRune(e.classRune(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		sb.WriteByte(byte('a' + e.rnd.Intn(26)))
+	case syntax.OpCapture, syntax.OpConcat:
+		for _, sub := range re.Sub {
+			e.pattern(sb, sub)
+		}
+	case syntax.OpAlternate:
+		e.pattern(sb, re.Sub[e.rnd.Intn(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		min, max := re.Min, re.Max
+
+		switch re.Op {
+		case syntax.OpStar:
+			min, max = 0, maxPatternRepeat
+		case syntax.OpPlus:
+			min, max = 1, maxPatternRepeat
+		case syntax.OpQuest:
+			min, max = 0, 1
+		}
+
+		if max < min {
+			max = min + maxPatternRepeat
+		}
+
+		for i := min + e.rnd.Intn(max-min+1); i > 0; i-- {
+			e.pattern(sb, re.Sub[0])
+		}
+	}
+}
+
+// classRune picks a rune from character class ranges, printable ASCII is preferred.
+func (e *exampler) classRune(ranges []rune) rune {
+	var printable []rune
+
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		if lo < ' ' {
+			lo = ' '
+		}
+
+		if hi > '~' {
+			hi = '~'
+		}
+
+		if lo <= hi {
+			printable = append(printable, lo, hi)
+		}
+	}
+
+	if len(printable) == 0 {
+		printable = ranges
+	}
+
+	if len(printable) < 2 {
+		return 'a'
+	}
+
+	i := 2 * e.rnd.Intn(len(printable)/2)
+
+	return printable[i] + rune(e.rnd.Intn(int(printable[i+1]-printable[i])+1))
+}
+
+// number synthesizes a value within bounds of schema, numbers are rounded to hundredths.
+func (e *exampler) number(s SchemaObj, integer bool) float64 {
+	step := 0.01
+	if integer {
+		step = 1
+	}
+
+	min, max := 1.0, 100.0
+
+	if s.Minimum != nil {
+		min = *s.Minimum
+		if s.ExclusiveMinimum {
+			min += step
+		}
+
+		if s.Maximum == nil {
+			max = min + 100
+		}
+	}
+
+	if s.Maximum != nil {
+		max = *s.Maximum
+		if s.ExclusiveMaximum {
+			max -= step
+		}
+
+		if s.Minimum == nil && max < min {
+			min = max - 100
+		}
+	}
+
+	if integer {
+		min, max = math.Ceil(min), math.Floor(max)
+	}
+
+	v := min
+	if max > min {
+		v = min + math.Floor(e.rnd.Float64()*(max-min)/step)*step
+	}
+
+	if s.MultipleOf > 0 {
+		v = math.Ceil(v/s.MultipleOf) * s.MultipleOf
+		if v > max {
+			v -= s.MultipleOf
+		}
+	}
+
+	if !integer {
+		v = math.Round(v*100) / 100
+	}
+
+	return v
+}
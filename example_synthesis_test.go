@@ -0,0 +1,139 @@
+package swgen_test
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/swgen"
+)
+
+type synthOwner struct {
+	Email string    `json:"email" format:"email" required:"true"`
+	Since time.Time `json:"since"`
+}
+
+type synthPet struct {
+	ID     int64       `json:"id" minimum:"10" maximum:"20"`
+	Name   string      `json:"name" minLength:"3" maxLength:"5"`
+	Code   string      `json:"code" pattern:"^[A-Z]{3}-\\d{2}$"`
+	Status string      `json:"status" enum:"available,sold"`
+	Kind   string      `json:"kind" default:"dog"`
+	Chip   string      `json:"chip" format:"uuid"`
+	IP     string      `json:"ip" format:"ipv4"`
+	Weight float64     `json:"weight" minimum:"0.5" maximum:"1" exclusiveMaximum:"true"`
+	Tags   []string    `json:"tags" minItems:"2"`
+	Owner  *synthOwner `json:"owner"`
+	Parent *synthPet   `json:"parent"`
+}
+
+func synthGenerator(seed int64) *swgen.Generator {
+	g := swgen.NewGenerator().SynthesizeExamples(true).SetExampleSeed(seed)
+	g.SetPathItem(swgen.PathItemInfo{Path: "/pet", Method: http.MethodGet, Response: new(synthPet)})
+
+	return g
+}
+
+func synthExample(t *testing.T, g *swgen.Generator) map[string]interface{} {
+	t.Helper()
+
+	data, err := g.GenDocument()
+	require.NoError(t, err)
+
+	var doc swgen.Document
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	examples, ok := doc.Paths["/pet"].Get.Responses[http.StatusOK].Examples.(map[string]interface{})
+	require.True(t, ok)
+
+	example, ok := examples["application/json"].(map[string]interface{})
+	require.True(t, ok)
+
+	return example
+}
+
+func TestGenerator_SynthesizeExamples(t *testing.T) {
+	g := synthGenerator(0)
+	example := synthExample(t, g)
+
+	body, err := json.Marshal(example)
+	require.NoError(t, err)
+	assert.NoError(t, swgen.NewResponseValidator(g).ValidateResponse(httptest.NewRequest(http.MethodGet, "/pet", nil),
+		http.StatusOK, http.Header{"Content-Type": []string{"application/json"}}, body))
+
+	assert.Equal(t, "dog", example["kind"])
+	assert.Contains(t, []interface{}{"available", "sold"}, example["status"])
+	assert.Regexp(t, `^[A-Z]{3}-\d{2}$`, example["code"])
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, example["chip"])
+	assert.NotNil(t, net.ParseIP(example["ip"].(string)).To4())
+	assert.InDelta(t, 15, example["id"], 5)
+	assert.Len(t, example["tags"], 2)
+	assert.NotContains(t, example, "parent", "recursive reference is skipped")
+
+	owner := example["owner"].(map[string]interface{})
+	assert.Regexp(t, regexp.MustCompile(`^[a-z]+@example\.com$`), owner["email"])
+
+	_, err = time.Parse(time.RFC3339, owner["since"].(string))
+	assert.NoError(t, err)
+
+	assertjson.EqualMarshal(t, mustMarshal(t, example), synthExample(t, synthGenerator(0)))
+	assert.NotEqual(t, example, synthExample(t, synthGenerator(42)))
+}
+
+func TestGenerator_ExampleFor(t *testing.T) {
+	g := unionGenerator()
+
+	example := g.ExampleFor(swgen.SchemaObj{Ref: "#/definitions/event"})
+	assert.Equal(t, map[string]interface{}{"id": int64(94), "kind": "created"}, example)
+
+	s := swgen.SchemaObj{}
+	s.Type = "string"
+	s.Example = "explicit"
+	assert.Equal(t, "explicit", g.ExampleFor(s))
+
+	assert.Nil(t, g.ExampleFor(swgen.SchemaObj{Ref: "#/definitions/unknown"}))
+}
+
+func TestGenerator_ExampleFor_snapshot(t *testing.T) {
+	g := swgen.NewGenerator()
+	ref := swgen.SchemaObj{Ref: "#/definitions/synthOwner"}
+
+	assert.Nil(t, g.ExampleFor(ref))
+
+	// Snapshot of definitions is refreshed after registration of new types.
+	g.ParseDefinition(synthOwner{})
+	assert.NotNil(t, g.ExampleFor(ref))
+
+	done := make(chan struct{})
+
+	for i := 0; i < 4; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+
+			assert.NotNil(t, g.ExampleFor(ref))
+		}()
+	}
+
+	g.SetExampleSeed(1)
+
+	for i := 0; i < 4; i++ {
+		<-done
+	}
+}
+
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return data
+}
@@ -0,0 +1,145 @@
+package swgen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// xExamples is an extension of body parameter with named request examples.
+const xExamples = "x-examples"
+
+var errNoExampleSchema = errors.New("operation has no schema for example")
+
+// setExamples validates examples of PathItemInfo against schemas of operation and adds them to operation.
+//
+// Request examples are added to body parameter as "x-examples", response examples are added to
+// ResponseObj.Examples for every produced MIME type. Examples of other responses are synthesized
+// if SynthesizeExamples is enabled.
+func (g *Generator) setExamples(info PathItemInfo, op *OperationObj) {
+	if len(info.RequestExamples) > 0 {
+		g.setRequestExamples(info.RequestExamples, op)
+	}
+
+	produces := op.Produces
+	if len(produces) == 0 {
+		produces = []string{mimeJSON}
+	}
+
+	for _, statusCode := range sortedIntKeys(info.ResponseExamples) {
+		resp, found := op.Responses[statusCode]
+		if !found || resp.Schema == nil {
+			g.addReflectError(fmt.Errorf("invalid response %d example: %w", statusCode, errNoExampleSchema))
+
+			continue
+		}
+
+		value, err := g.exampleValue(*resp.Schema, info.ResponseExamples[statusCode], "response",
+			fmt.Sprintf("invalid response %d example", statusCode))
+		if err != nil {
+			g.addReflectError(err)
+
+			continue
+		}
+
+		resp.Examples = mimeExamples(produces, resp.content, value)
+		op.Responses[statusCode] = resp
+	}
+
+	if !g.synthesizeExamples {
+		return
+	}
+
+	// Definitions of operation being registered are not in snapshot of ExampleFor yet.
+	var definitions map[string]SchemaObj
+
+	for _, statusCode := range sortedIntKeys(op.Responses) {
+		resp := op.Responses[statusCode]
+		if resp.Schema == nil || resp.Examples != nil {
+			continue
+		}
+
+		if definitions == nil {
+			definitions = g.definitions.GenDefinitions()
+		}
+
+		value := newExampler(g.exampleSeed, definitions).value(*resp.Schema)
+		if value == nil {
+			continue
+		}
+
+		resp.Examples = mimeExamples(produces, resp.content, value)
+		op.Responses[statusCode] = resp
+	}
+}
+
+// mimeExamples maps MIME types to example value, MIME types with own schema in content are skipped.
+func mimeExamples(mimeTypes []string, content map[string]*SchemaObj, value interface{}) map[string]interface{} {
+	examples := make(map[string]interface{}, len(mimeTypes))
+	for _, mime := range mimeTypes {
+		if _, ok := content[mime]; ok {
+			continue
+		}
+
+		examples[mime] = value
+	}
+
+	return examples
+}
+
+func (g *Generator) setRequestExamples(requestExamples map[string]interface{}, op *OperationObj) {
+	for i, p := range op.Parameters {
+		if p.In != "body" || p.Schema == nil {
+			continue
+		}
+
+		examples := make(map[string]interface{}, len(requestExamples))
+
+		for _, name := range sortedKeys(requestExamples) {
+			value, err := g.exampleValue(*p.Schema, requestExamples[name], "body",
+				fmt.Sprintf("invalid request example %q", name))
+			if err != nil {
+				g.addReflectError(err)
+
+				continue
+			}
+
+			examples[name] = value
+		}
+
+		op.Parameters[i].AddExtendedField(xExamples, examples)
+
+		return
+	}
+
+	g.addReflectError(fmt.Errorf("invalid request example: %w", errNoExampleSchema))
+}
+
+// exampleValue converts example to JSON value and checks it against schema.
+func (g *Generator) exampleValue(schema SchemaObj, example interface{}, in, message string) (interface{}, error) {
+	data, err := json.Marshal(example)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", message, err)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("%s: %w", message, err)
+	}
+
+	jsonSchema, err := g.JSONSchema(schema)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", message, err)
+	}
+
+	compiled, err := compileJSONSchema(jsonSchema)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to compile schema: %w", message, err)
+	}
+
+	if violations := validateValue(compiled, in, "", value); len(violations) > 0 {
+		return nil, ValidationError{Message: message, Violations: violations}
+	}
+
+	return value, nil
+}
@@ -0,0 +1,147 @@
+package swgen_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/swgen"
+)
+
+type exampleOrder struct {
+	ID       int    `json:"id,omitempty"`
+	Item     string `json:"item" required:"true" minLength:"1"`
+	Quantity int    `json:"quantity" minimum:"1"`
+}
+
+type exampleError struct {
+	Error string `json:"error"`
+}
+
+func examplesInfo() swgen.PathItemInfo {
+	info := swgen.PathItemInfo{
+		Path:     "/orders",
+		Method:   http.MethodPost,
+		Request:  new(exampleOrder),
+		Response: new(exampleOrder),
+		RequestExamples: map[string]interface{}{
+			"single": exampleOrder{Item: "apple", Quantity: 1},
+			"bulk":   exampleOrder{Item: "pear", Quantity: 100},
+		},
+		ResponseExamples: map[int]interface{}{
+			http.StatusOK:         exampleOrder{ID: 1, Item: "apple", Quantity: 1},
+			http.StatusBadRequest: exampleError{Error: "invalid quantity"},
+		},
+	}
+	info.AddResponse(http.StatusBadRequest, new(exampleError))
+
+	return info
+}
+
+func TestPathItemInfo_examples(t *testing.T) {
+	g := swgen.NewGenerator()
+	g.SetPathItem(examplesInfo())
+
+	data, err := g.GenDocument()
+	require.NoError(t, err)
+
+	var doc swgen.Document
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assertjson.EqualMarshal(t, []byte(`{
+	  "summary":"","description":"","parameters":[
+	    {
+	      "name":"body","in":"body","required":true,
+	      "schema":{"$ref":"#/definitions/exampleOrder"},
+	      "x-examples":{
+	        "bulk":{"item":"pear","quantity":100},
+	        "single":{"item":"apple","quantity":1}
+	      }
+	    }
+	  ],
+	  "responses":{
+	    "200":{
+	      "description":"OK","schema":{"$ref":"#/definitions/exampleOrder"},
+	      "examples":{"application/json":{"id":1,"item":"apple","quantity":1}}
+	    },
+	    "400":{
+	      "description":"Bad Request","schema":{"$ref":"#/definitions/exampleError"},
+	      "examples":{"application/json":{"error":"invalid quantity"}}
+	    }
+	  }
+	}`), doc.Paths["/orders"].Post)
+
+	data, err = g.GenDocumentOAS3()
+	require.NoError(t, err)
+
+	var oas3 map[string]interface{}
+
+	require.NoError(t, json.Unmarshal(data, &oas3))
+	assertjson.EqualMarshal(t, []byte(`{
+	  "requestBody":{
+	    "content":{
+	      "application/json":{
+	        "schema":{"$ref":"#/components/schemas/exampleOrder"},
+	        "examples":{
+	          "bulk":{"value":{"item":"pear","quantity":100}},
+	          "single":{"value":{"item":"apple","quantity":1}}
+	        }
+	      }
+	    },
+	    "required":true
+	  },
+	  "responses":{
+	    "200":{
+	      "description":"OK",
+	      "content":{
+	        "application/json":{
+	          "schema":{"$ref":"#/components/schemas/exampleOrder"},
+	          "example":{"id":1,"item":"apple","quantity":1}
+	        }
+	      }
+	    },
+	    "400":{
+	      "description":"Bad Request",
+	      "content":{
+	        "application/json":{
+	          "schema":{"$ref":"#/components/schemas/exampleError"},
+	          "example":{"error":"invalid quantity"}
+	        }
+	      }
+	    }
+	  }
+	}`), oas3["paths"].(map[string]interface{})["/orders"].(map[string]interface{})["post"])
+}
+
+func TestPathItemInfo_examples_invalid(t *testing.T) {
+	info := examplesInfo()
+	info.RequestExamples["stale"] = map[string]interface{}{"item": "", "quantity": "many"}
+	info.ResponseExamples[http.StatusOK] = exampleError{Error: "not an order"}
+	info.ResponseExamples[http.StatusNotFound] = exampleError{Error: "not found"}
+
+	g := swgen.NewGenerator()
+	_, err := g.TrySetPathItem(info)
+	require.Error(t, err)
+
+	var re swgen.ReflectError
+
+	require.True(t, errors.As(err, &re))
+	require.Len(t, re.Errors, 3)
+
+	var ve swgen.ValidationError
+
+	require.True(t, errors.As(re.Errors[0], &ve))
+	assert.Equal(t, `invalid request example "stale"`, ve.Message)
+	assert.Len(t, ve.Violations, 2)
+
+	assert.Equal(t, `invalid response 200 example: response: missing properties: "item"`, re.Errors[1].Error())
+	assert.Equal(t, "invalid response 404 example: operation has no schema for example", re.Errors[2].Error())
+
+	assert.Panics(t, func() {
+		swgen.NewGenerator().SetPathItem(info)
+	})
+}
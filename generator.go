@@ -10,8 +10,12 @@ import (
 	"github.com/swaggest/jsonschema-go"
 	"github.com/swaggest/openapi-go/openapi3"
 	"github.com/swaggest/refl"
+	"github.com/swaggest/swgen/internal/naming"
 )
 
+// defaultSchemes are schemes of document of a new Generator.
+var defaultSchemes = []string{"http", "https"}
+
 // Generator create swagger document.
 type Generator struct {
 	oas3Proxy *openapi3.Reflector
@@ -27,13 +31,30 @@ type Generator struct {
 	definitions      defMap                           // list of all definition objects
 	defQueue         map[refl.TypeString]reflect.Type // queue of reflect.Type objects waiting for analysis
 	paths            map[string]PathItem              // list all of paths object
+	pathPatterns     map[string]string                // path templates with gorilla.Mux-style regexps by path
 	typesMap         map[refl.TypeString]interface{}
+	compositions     map[refl.TypeString]composition // polymorphic types with their variants
+	comments         map[string]string               // Go doc comments by type or field name
 	defaultResponses map[int]interface{}
 
 	indentJSON            bool
 	reflectGoTypes        bool
 	addPackagePrefix      bool
 	capitalizeDefinitions bool
+	synthesizeExamples    bool
+	exampleSeed           int64                // seed of random values in synthesized examples
+	exampleDefinitions    map[string]SchemaObj // definitions snapshot of ExampleFor
+	exampleVersion        uint64               // cache version of exampleDefinitions
+	cookieParamPolicy     CookieParamPolicy
+	collectionFormat      string            // default collectionFormat of array parameters
+	collectionFormatsIn   map[string]string // collectionFormat of array parameters by location
+
+	scope         reflectScope // currently reflected field
+	reflectErrors []FieldError // problems collected during current reflection
+
+	cacheMu      sync.Mutex                   // mutex for cached document renderings
+	cache        map[string]*renderedDocument // document renderings by format and host
+	cacheVersion uint64                       // incremented on every change of Generator
 
 	mu sync.Mutex // mutex for Generator's public API
 }
@@ -47,11 +68,12 @@ func (m *defMap) GenDefinitions() (result map[string]SchemaObj) {
 
 	result = make(map[string]SchemaObj)
 
-	for t, typeDef := range *m {
+	// Go types are iterated in sorted order for stable resolution of name collisions.
+	for _, t := range sortedKeys(*m) {
+		typeDef := (*m)[refl.TypeString(t)]
 		typeDef.Ref = "" // first (top) level Swagger definitions are never references
 		if _, ok := result[typeDef.TypeName]; ok {
-			typeName := t
-			result[string(typeName)] = typeDef
+			result[t] = typeDef
 		} else {
 			result[typeDef.TypeName] = typeDef
 		}
@@ -69,9 +91,11 @@ func NewGenerator() *Generator {
 
 	g.defQueue = make(map[refl.TypeString]reflect.Type)
 	g.paths = make(map[string]PathItem) // list all of paths object
+	g.pathPatterns = make(map[string]string)
 	g.typesMap = make(map[refl.TypeString]interface{})
+	g.compositions = make(map[refl.TypeString]composition)
 
-	g.doc.Schemes = []string{"http", "https"}
+	g.doc.Schemes = append([]string(nil), defaultSchemes...)
 	g.doc.Paths = make(map[string]PathItem)
 	g.doc.Definitions = make(map[string]SchemaObj)
 	g.doc.SecurityDefinitions = make(map[string]SecurityDef)
@@ -88,6 +112,7 @@ func NewGenerator() *Generator {
 func (g *Generator) IndentJSON(enabled bool) *Generator {
 	g.mu.Lock()
 	g.indentJSON = enabled
+	g.ResetDocumentCache()
 	g.mu.Unlock()
 
 	return g
@@ -97,6 +122,7 @@ func (g *Generator) IndentJSON(enabled bool) *Generator {
 func (g *Generator) ReflectGoTypes(enabled bool) *Generator {
 	g.mu.Lock()
 	g.reflectGoTypes = enabled
+	g.ResetDocumentCache()
 	g.mu.Unlock()
 
 	return g
@@ -107,6 +133,7 @@ func (g *Generator) ReflectGoTypes(enabled bool) *Generator {
 func (g *Generator) AddPackagePrefix(enabled bool) *Generator {
 	g.mu.Lock()
 	g.addPackagePrefix = enabled
+	g.ResetDocumentCache()
 	g.mu.Unlock()
 
 	return g
@@ -117,6 +144,7 @@ func (g *Generator) AddPackagePrefix(enabled bool) *Generator {
 func (g *Generator) CapitalizeDefinitions(enabled bool) *Generator {
 	g.mu.Lock()
 	g.capitalizeDefinitions = enabled
+	g.ResetDocumentCache()
 	g.mu.Unlock()
 
 	return g
@@ -129,6 +157,7 @@ func (g *Generator) AddDefaultResponse(httpCode int, response interface{}) {
 	}
 
 	g.defaultResponses[httpCode] = response
+	g.ResetDocumentCache()
 }
 
 // EnableCORS enable HTTP handler support CORS.
@@ -175,6 +204,8 @@ func (g *Generator) SetHost(host string) *Generator {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	g.ResetDocumentCache()
+
 	g.host = host
 
 	if g.oas3Proxy != nil {
@@ -189,6 +220,8 @@ func (g *Generator) SetBasePath(basePath string) *Generator {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	g.ResetDocumentCache()
+
 	g.doc.BasePath = "/" + strings.Trim(basePath, "/")
 
 	if g.oas3Proxy != nil {
@@ -203,6 +236,8 @@ func (g *Generator) SetContact(name, url, email string) *Generator {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	g.ResetDocumentCache()
+
 	g.doc.Info.Contact = ContactObj{
 		Name:  name,
 		URL:   url,
@@ -233,6 +268,7 @@ func (g *Generator) SetOAS3Proxy(oas3Proxy *openapi3.Reflector) {
 
 	oas3Proxy.DefaultOptions = append(oas3Proxy.DefaultOptions,
 		jsonschema.InterceptType(JSONSchemaInterceptType),
+		jsonschema.InterceptType(g.proxyComposition(oas3Proxy)),
 	)
 
 	g.oas3Proxy = oas3Proxy
@@ -243,6 +279,8 @@ func (g *Generator) SetInfo(title, description, term, version string) *Generator
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	g.ResetDocumentCache()
+
 	g.doc.Info = InfoObj{
 		Title:          title,
 		Description:    description,
@@ -272,6 +310,8 @@ func (g *Generator) SetLicense(name, url string) *Generator {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	g.ResetDocumentCache()
+
 	g.doc.Info.License = LicenseObj{
 		Name: name,
 		URL:  url,
@@ -293,6 +333,8 @@ func (g *Generator) AddExtendedField(name string, value interface{}) *Generator
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	g.ResetDocumentCache()
+
 	g.doc.AddExtendedField(name, value)
 
 	if g.oas3Proxy != nil {
@@ -307,6 +349,8 @@ func (g *Generator) AddSecurityDefinition(name string, def SecurityDef) *Generat
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	g.ResetDocumentCache()
+
 	if g.oas3Proxy != nil {
 		ss := openapi3.SecuritySchemeOrRef{}
 		sss := ss.SecuritySchemeEns()
@@ -355,12 +399,13 @@ func (g *Generator) AddSecurityDefinition(name string, def SecurityDef) *Generat
 	}
 
 	if def.Type == SecurityBearerToken {
+		def.isBearer = true
 		def.Type = SecurityAPIKey
 		def.In = APIKeyInHeader
 		def.Name = "Authorization"
 
 		if def.Description == "" {
-			def.Description = "Should be in form: 'Bearer <token_value>'"
+			def.Description = bearerDescription
 		}
 	}
 
@@ -374,6 +419,8 @@ func (g *Generator) AddTypeMap(source interface{}, destination interface{}) *Gen
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	g.ResetDocumentCache()
+
 	goTypeName := refl.GoType(refl.DeepIndirect(reflect.TypeOf(source)))
 	g.typesMap[goTypeName] = destination
 
@@ -401,6 +448,10 @@ func (g *Generator) genDocument(host *string) ([]byte, error) {
 		err  error
 	)
 
+	if err = g.cookieParamsError(); err != nil {
+		return nil, err
+	}
+
 	// ensure that all definition in queue is parsed before generating
 	g.parseDefInQueue()
 	g.doc.Definitions = g.definitions.GenDefinitions()
@@ -414,7 +465,7 @@ func (g *Generator) genDocument(host *string) ([]byte, error) {
 	g.doc.Paths = make(map[string]PathItem)
 
 	for path, item := range g.paths {
-		g.doc.Paths[path] = item
+		g.doc.Paths[path] = g.swagger2PathItem(item)
 	}
 
 	if g.indentJSON {
@@ -433,23 +484,23 @@ func (g *Generator) GenDocument() ([]byte, error) {
 }
 
 // ServeHTTP implements http.Handler to server swagger.json document.
+//
+// Document is served in YAML if request path ends with ".yaml" or Accept header asks for "application/yaml".
 func (g *Generator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	data, err := g.genDocument(&r.URL.Host)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-
-	g.writeCORSHeaders(w)
-
-	_, err = w.Write(data)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-	}
+	g.serveDocument(w, r, &r.URL.Host)
 }
 
 // Document is an accessor to generated document.
 func (g *Generator) Document() Document {
 	return g.doc
 }
+
+// sortedKeys returns sorted keys of a map with string keys.
+func sortedKeys(m interface{}) []string {
+	return naming.SortedKeys(m)
+}
+
+// sortedIntKeys returns sorted keys of a map with int keys, e.g. status codes.
+func sortedIntKeys(m interface{}) []int {
+	return naming.SortedIntKeys(m)
+}
@@ -687,3 +687,82 @@ func TestGenerator_GenDocument_CorrectRef(t *testing.T) {
 	expected := readTestFile(t, "struct_collision_correct_ref.json")
 	assert.JSONEq(t, expected, string(bytes), coloredJSONDiff(expected, string(bytes)))
 }
+
+func TestGenerator_GenDocument_Deterministic(t *testing.T) {
+	gen := func() []byte {
+		g := NewGenerator()
+		g.IndentJSON(true)
+		g.AddDefaultResponse(http.StatusBadRequest, new(variation.Entity))
+		g.AddDefaultResponse(http.StatusConflict, new(experiment.Entity))
+		g.AddDefaultResponse(http.StatusNotFound, new(variation.Metadata))
+		g.AddDefaultResponse(http.StatusUnprocessableEntity, new(experiment.Metadata))
+
+		info := PathItemInfo{
+			Method:   http.MethodPost,
+			Path:     "/any",
+			Request:  new(experiment.PostRequest),
+			Response: new(experiment.Entity),
+			SecurityOAuth2: map[string][]string{
+				"d": {"write"},
+				"b": {"read"},
+				"c": nil,
+				"a": {"admin"},
+			},
+		}
+		g.SetPathItem(info)
+
+		b, err := g.GenDocument()
+		assert.NoError(t, err)
+
+		return b
+	}
+
+	expected := gen()
+
+	var doc Document
+	assert.NoError(t, json.Unmarshal(expected, &doc))
+	assert.Equal(t, []map[string][]string{
+		{"a": {"admin"}}, {"b": {"read"}}, {"c": nil}, {"d": {"write"}},
+	}, doc.Paths["/any"].Post.Security)
+
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, string(expected), string(gen()))
+	}
+}
+
+func TestDefMap_GenDefinitions_collision(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		m := defMap{
+			"b.Entity": SchemaObj{TypeName: "Entity", CommonFields: CommonFields{Description: "b"}},
+			"a.Entity": SchemaObj{TypeName: "Entity", CommonFields: CommonFields{Description: "a"}},
+			"c.Entity": SchemaObj{TypeName: "Entity", CommonFields: CommonFields{Description: "c"}},
+		}
+
+		defs := m.GenDefinitions()
+		assert.Equal(t, "a", defs["Entity"].Description)
+		assert.Equal(t, "b", defs["b.Entity"].Description)
+		assert.Equal(t, "c", defs["c.Entity"].Description)
+	}
+}
+
+func TestGenerator_WalkJSONSchemaResponses_order(t *testing.T) {
+	g := NewGenerator()
+	g.AddDefaultResponse(http.StatusInternalServerError, new(variation.Metadata))
+
+	for _, path := range []string{"/c", "/a", "/b"} {
+		g.SetPathItem(PathItemInfo{Method: http.MethodPost, Path: path, Response: new(variation.Entity)})
+		g.SetPathItem(PathItemInfo{Method: http.MethodGet, Path: path, Response: new(variation.Entity)})
+	}
+
+	var visited []string
+
+	assert.NoError(t, g.WalkJSONSchemaResponses(func(path, method string, statusCode int, schema map[string]interface{}) {
+		visited = append(visited, fmt.Sprintf("%s %s %d", method, path, statusCode))
+	}))
+
+	assert.Equal(t, []string{
+		"GET /a 200", "GET /a 500", "POST /a 200", "POST /a 500",
+		"GET /b 200", "GET /b 500", "POST /b 200", "POST /b 500",
+		"GET /c 200", "GET /c 500", "POST /c 200", "POST /c 500",
+	}, visited)
+}
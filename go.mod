@@ -3,12 +3,17 @@ module github.com/swaggest/swgen
 go 1.13
 
 require (
-	github.com/bool64/dev v0.1.33
+	github.com/bool64/dev v0.2.43
+	github.com/go-chi/chi/v5 v5.0.8
+	github.com/gorilla/mux v1.8.0
 	github.com/pkg/errors v0.9.1
+	github.com/santhosh-tekuri/jsonschema/v3 v3.1.0
 	github.com/stretchr/testify v1.7.0
 	github.com/swaggest/assertjson v1.6.4
 	github.com/swaggest/jsonschema-go v0.3.19
 	github.com/swaggest/openapi-go v0.2.10
 	github.com/swaggest/refl v0.1.7
+	github.com/swaggest/swgui v1.8.5
 	github.com/yudai/gojsondiff v1.0.0
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -0,0 +1,30 @@
+// Package commented contains types with doc comments.
+package commented
+
+// Pet is a pet in store.
+type Pet struct {
+	// ID is a unique identifier.
+	ID   int64  `json:"id"`
+	Name string `json:"name"` // Name of pet.
+
+	// Kind is overridden with tag.
+	Kind string `json:"kind" description:"Kind of animal."`
+
+	Owner Owner `json:"owner"`
+}
+
+type (
+	// Owner has pets.
+	Owner struct {
+		Name string `json:"name"` // Full name.
+	}
+
+	// FindPets searches pets by name.
+	//
+	// Results are paginated.
+	FindPets struct {
+		// Name is a search query.
+		Name  string `query:"name"`
+		Limit int    `query:"limit" description:"Page size."`
+	}
+)
@@ -0,0 +1,67 @@
+// Package naming contains helpers shared by generators of Go code and documents.
+package naming
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var initialisms = map[string]string{
+	"id": "ID", "url": "URL", "uri": "URI", "http": "HTTP", "api": "API", "json": "JSON", "uuid": "UUID",
+	"ip": "IP", "html": "HTML", "xml": "XML", "sql": "SQL",
+}
+
+// Exported converts identifier to exported Go name, e.g. "user_id" to "UserID".
+func Exported(s string) string {
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	res := ""
+
+	for _, p := range parts {
+		if i, ok := initialisms[strings.ToLower(p)]; ok {
+			res += i
+
+			continue
+		}
+
+		res += strings.ToUpper(p[:1]) + p[1:]
+	}
+
+	if res == "" || unicode.IsDigit(rune(res[0])) {
+		res = "X" + res
+	}
+
+	return res
+}
+
+// SortedKeys returns sorted keys of a map with string keys.
+func SortedKeys(m interface{}) []string {
+	v := reflect.ValueOf(m)
+	keys := make([]string, 0, v.Len())
+
+	for _, k := range v.MapKeys() {
+		keys = append(keys, k.String())
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// SortedIntKeys returns sorted keys of a map with int keys, e.g. status codes.
+func SortedIntKeys(m interface{}) []int {
+	v := reflect.ValueOf(m)
+	keys := make([]int, 0, v.Len())
+
+	for _, k := range v.MapKeys() {
+		keys = append(keys, int(k.Int()))
+	}
+
+	sort.Ints(keys)
+
+	return keys
+}
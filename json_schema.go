@@ -144,6 +144,65 @@ func (sb *schemaBuilder) jsonSchemaPlain(s SchemaObj, cfg *JSONSchemaConfig) (ma
 		res["items"] = jsonSchema
 	}
 
+	delete(res, "x-oneOf")
+	delete(res, "x-anyOf")
+
+	if s.Discriminator != "" {
+		return sb.discriminatedUnion(res, s, cfg)
+	}
+
+	for keyword, schemas := range map[string][]SchemaObj{
+		compositionOneOf: s.OneOf,
+		compositionAnyOf: s.AnyOf,
+		compositionAllOf: s.AllOf,
+	} {
+		if len(schemas) == 0 {
+			continue
+		}
+
+		items := make([]interface{}, 0, len(schemas))
+
+		for _, schema := range schemas {
+			jsonSchema, err := sb.jsonSchemaPlain(schema, cfg)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, jsonSchema)
+		}
+
+		res[keyword] = items
+	}
+
+	return res, nil
+}
+
+// discriminatedUnion renders variants of tagged union constrained with discriminator values.
+func (sb *schemaBuilder) discriminatedUnion(res map[string]interface{}, s SchemaObj, cfg *JSONSchemaConfig) (map[string]interface{}, error) {
+	delete(res, "discriminator")
+	delete(res, "x-discriminator-mapping")
+
+	variants := make([]interface{}, 0, len(s.DiscriminatorMapping))
+
+	for _, value := range sortedKeys(s.DiscriminatorMapping) {
+		ref, err := sb.jsonSchemaPlain(SchemaObj{Ref: s.DiscriminatorMapping[value]}, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		variants = append(variants, map[string]interface{}{
+			"allOf": []interface{}{
+				ref,
+				map[string]interface{}{
+					"required":   []interface{}{s.Discriminator},
+					"properties": map[string]interface{}{s.Discriminator: map[string]interface{}{"enum": []interface{}{value}}},
+				},
+			},
+		})
+	}
+
+	res[compositionOneOf] = variants
+
 	return res, nil
 }
 
@@ -158,10 +217,27 @@ func (g *Generator) ParamJSONSchema(p ParamObj, cfg ...JSONSchemaConfig) (map[st
 	p.Required = false
 	p.CollectionFormat = ""
 
+	if _, ok := p.data[xIn]; ok {
+		data := make(map[string]interface{}, len(p.data))
+		for k, v := range p.data {
+			if k != xIn {
+				data[k] = v
+			}
+		}
+
+		p.data = data
+	}
+
 	if p.Type == "file" {
 		p.Type = ""
 	}
 
+	if p.Items != nil && p.Items.Type == "file" {
+		items := *p.Items
+		items.Type = ""
+		p.Items = &items
+	}
+
 	res, err := jsonRecode(p)
 
 	return res, err
@@ -208,8 +284,10 @@ func (g *Generator) GetJSONSchemaRequestGroups(op *OperationObj, cfg ...JSONSche
 			continue
 		}
 
-		if _, ok := requestSchemas[param.In]; !ok {
-			requestSchemas[param.In] = ObjectJSONSchema{
+		in := paramIn(param)
+
+		if _, ok := requestSchemas[in]; !ok {
+			requestSchemas[in] = ObjectJSONSchema{
 				Schema:     "http://json-schema.org/draft-04/schema#",
 				Type:       "object",
 				Required:   []string{},
@@ -218,12 +296,12 @@ func (g *Generator) GetJSONSchemaRequestGroups(op *OperationObj, cfg ...JSONSche
 		}
 
 		if param.Required {
-			rs := requestSchemas[param.In]
+			rs := requestSchemas[in]
 			rs.Required = append(rs.Required, param.Name)
-			requestSchemas[param.In] = rs
+			requestSchemas[in] = rs
 		}
 
-		requestSchemas[param.In].Properties[param.Name], err = g.ParamJSONSchema(param, cfg...)
+		requestSchemas[in].Properties[param.Name], err = g.ParamJSONSchema(param, cfg...)
 		if err != nil {
 			return nil, err
 		}
@@ -233,60 +311,74 @@ func (g *Generator) GetJSONSchemaRequestGroups(op *OperationObj, cfg ...JSONSche
 }
 
 // WalkJSONSchemaRequestGroups iterates over all request parameters grouped by path, method and in into an instance of JSON Schema.
+//
+// Operations are visited in order of path, method and in.
 func (g *Generator) WalkJSONSchemaRequestGroups(function func(path, method, in string, schema ObjectJSONSchema)) error {
-	for path, pi := range g.paths {
-		for method, op := range pi.Map() {
-			requestSchemas, err := g.GetJSONSchemaRequestGroups(op)
-			if err != nil {
-				return errors.Wrapf(err, "failed to get schema request groups schemas for %s %s", method, path)
-			}
+	return g.walkOperations(func(path, method string, op *OperationObj) error {
+		requestSchemas, err := g.GetJSONSchemaRequestGroups(op)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get schema request groups schemas for %s %s", method, path)
+		}
 
-			for in, schema := range requestSchemas {
-				function(path, method, in, schema)
-			}
+		for _, in := range sortedKeys(requestSchemas) {
+			function(path, method, in, requestSchemas[in])
 		}
-	}
 
-	return nil
+		return nil
+	})
 }
 
-// WalkJSONSchemaRequestBodies iterates over all request bodies.
+// WalkJSONSchemaRequestBodies iterates over all request bodies in order of path and method.
 func (g *Generator) WalkJSONSchemaRequestBodies(function func(path, method string, schema map[string]interface{})) error {
-	for path, pi := range g.paths {
-		for method, op := range pi.Map() {
-			for _, param := range op.Parameters {
-				if param.In == "body" {
-					schema, err := g.ParamJSONSchema(param)
-					if err != nil {
-						return err
-					}
-
-					function(path, method, schema)
+	return g.walkOperations(func(path, method string, op *OperationObj) error {
+		for _, param := range op.Parameters {
+			if param.In == "body" {
+				schema, err := g.ParamJSONSchema(param)
+				if err != nil {
+					return err
 				}
+
+				function(path, method, schema)
 			}
 		}
-	}
 
-	return nil
+		return nil
+	})
 }
 
 // WalkJSONSchemaResponses iterates over all responses grouped by path, method and status code into an instance of JSON Schema.
+//
+// Responses are visited in order of path, method and status code.
 func (g *Generator) WalkJSONSchemaResponses(function func(path, method string, statusCode int, schema map[string]interface{})) error {
-	for path, pi := range g.paths {
-		for method, op := range pi.Map() {
-			for statusCode, resp := range op.Responses {
-				if resp.Schema == nil {
-					continue
-				}
+	return g.walkOperations(func(path, method string, op *OperationObj) error {
+		for _, statusCode := range sortedIntKeys(op.Responses) {
+			resp := op.Responses[statusCode]
+			if resp.Schema == nil {
+				continue
+			}
 
-				schema, err := g.JSONSchema(*resp.Schema)
-				if err != nil {
-					return errors.Wrapf(err, "failed to get response schema for %s %s %d", method, path, statusCode)
-				}
+			schema, err := g.JSONSchema(*resp.Schema)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get response schema for %s %s %d", method, path, statusCode)
+			}
+
+			schema["$schema"] = "http://json-schema.org/draft-04/schema#"
+
+			function(path, method, statusCode, schema)
+		}
+
+		return nil
+	})
+}
 
-				schema["$schema"] = "http://json-schema.org/draft-04/schema#"
+// walkOperations iterates over registered operations sorted by path and method.
+func (g *Generator) walkOperations(function func(path, method string, op *OperationObj) error) error {
+	for _, path := range sortedKeys(g.paths) {
+		ops := g.paths[path].Map()
 
-				function(path, method, statusCode, schema)
+		for _, method := range sortedKeys(ops) {
+			if err := function(path, method, ops[method]); err != nil {
+				return err
 			}
 		}
 	}
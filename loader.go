@@ -0,0 +1,175 @@
+package swgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/swaggest/refl"
+)
+
+// MergeConflict describes a document entity that could not be merged because generator already has it.
+type MergeConflict struct {
+	Kind string // "info", "host", "basePath", "schemes", "path", "definition" or "securityDefinition".
+	Name string // E.g. "GET /pets", "Pet".
+}
+
+// MergeError lists all conflicts found while merging a document, conflicting entities are skipped.
+type MergeError struct {
+	Conflicts []MergeConflict
+}
+
+// Error implements error.
+func (e MergeError) Error() string {
+	msgs := make([]string, 0, len(e.Conflicts))
+	for _, c := range e.Conflicts {
+		msgs = append(msgs, c.Kind+" "+c.Name)
+	}
+
+	return "merge conflicts: " + strings.Join(msgs, ", ")
+}
+
+// LoadDocument creates a Generator seeded with Swagger 2.0 document.
+//
+// Reflected path items can be added on top of loaded document with SetPathItem.
+func LoadDocument(data []byte) (*Generator, error) {
+	doc := Document{}
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	if doc.Version != "2.0" {
+		return nil, fmt.Errorf("unsupported document version %q, 2.0 expected", doc.Version)
+	}
+
+	g := NewGenerator()
+
+	if err := g.Merge(doc); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// Merge adds info, security definitions, paths and definitions of a Swagger 2.0 document to Generator.
+//
+// Entities that are already present in Generator are not overwritten and reported with MergeError.
+// Definitions are matched to Go types by "x-go-type" vendor extension, if available, so that reflection
+// of such types reuses merged definition.
+// Merged paths are not proxied to OpenAPI 3 reflector, use GenDocumentOAS3 to render them.
+func (g *Generator) Merge(doc Document) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.ResetDocumentCache()
+
+	var conflicts []MergeConflict
+
+	if doc.Info != (InfoObj{}) {
+		if g.doc.Info == (InfoObj{}) {
+			g.doc.Info = doc.Info
+		} else if g.doc.Info != doc.Info {
+			conflicts = append(conflicts, MergeConflict{Kind: "info", Name: doc.Info.Title})
+		}
+	}
+
+	if doc.Host != "" {
+		if g.host == "" {
+			g.host = doc.Host
+		} else if g.host != doc.Host {
+			conflicts = append(conflicts, MergeConflict{Kind: "host", Name: doc.Host})
+		}
+	}
+
+	if doc.BasePath != "" {
+		if g.doc.BasePath == "/" {
+			g.doc.BasePath = doc.BasePath
+		} else if g.doc.BasePath != doc.BasePath {
+			conflicts = append(conflicts, MergeConflict{Kind: "basePath", Name: doc.BasePath})
+		}
+	}
+
+	// Default schemes of Generator are replaced.
+	if len(doc.Schemes) > 0 {
+		if reflect.DeepEqual(g.doc.Schemes, defaultSchemes) {
+			g.doc.Schemes = doc.Schemes
+		} else if !reflect.DeepEqual(g.doc.Schemes, doc.Schemes) {
+			conflicts = append(conflicts, MergeConflict{Kind: "schemes", Name: strings.Join(doc.Schemes, ",")})
+		}
+	}
+
+	for name, value := range doc.data {
+		if _, ok := g.doc.data[name]; !ok {
+			g.doc.AddExtendedField(name, value)
+		}
+	}
+
+	for _, name := range sortedKeys(doc.SecurityDefinitions) {
+		def := doc.SecurityDefinitions[name]
+
+		if existing, ok := g.doc.SecurityDefinitions[name]; ok {
+			if !reflect.DeepEqual(existing, def) {
+				conflicts = append(conflicts, MergeConflict{Kind: "securityDefinition", Name: name})
+			}
+
+			continue
+		}
+
+		g.doc.SecurityDefinitions[name] = def
+	}
+
+	for _, name := range sortedKeys(doc.Definitions) {
+		if !g.mergeDefinition(name, doc.Definitions[name]) {
+			conflicts = append(conflicts, MergeConflict{Kind: "definition", Name: name})
+		}
+	}
+
+	for _, path := range sortedKeys(doc.Paths) {
+		item := g.paths[path]
+		ops := doc.Paths[path].Map()
+
+		for _, method := range sortedKeys(ops) {
+			if item.HasMethod(method) {
+				conflicts = append(conflicts, MergeConflict{Kind: "path", Name: method + " " + path})
+
+				continue
+			}
+
+			item.setOperation(method, ops[method])
+		}
+
+		g.paths[path] = item
+	}
+
+	if len(conflicts) > 0 {
+		return MergeError{Conflicts: conflicts}
+	}
+
+	return nil
+}
+
+// mergeDefinition adds a named schema to definitions and returns false if name or Go type is already taken.
+func (g *Generator) mergeDefinition(name string, def SchemaObj) bool {
+	key := refl.TypeString(refDefinitionPrefix + name)
+	if def.GoType != "" {
+		key = refl.TypeString(strings.TrimLeft(def.GoType, "*"))
+	}
+
+	if _, ok := g.definitions[key]; ok {
+		return false
+	}
+
+	if allocated, ok := g.definitionAlloc[name]; ok && allocated != key {
+		return false
+	}
+
+	def.TypeName = name
+	def.Ref = refDefinitionPrefix + name
+
+	g.definitionAlloc[name] = key
+	g.definitions[key] = def
+
+	return true
+}
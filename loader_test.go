@@ -0,0 +1,130 @@
+package swgen_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/swgen"
+)
+
+const legacySpec = `{
+  "swagger": "2.0",
+  "info": {"title": "Legacy", "description": "", "termsOfService": "", "contact": {"name": ""}, "license": {"name": ""}, "version": "1.0"},
+  "host": "legacy.example.com",
+  "basePath": "/api",
+  "schemes": ["https"],
+  "paths": {
+    "/pets/{id}": {
+      "parameters": [{"name": "id", "in": "path", "type": "integer", "required": true}],
+      "get": {
+        "summary": "Get pet",
+        "description": "",
+        "responses": {"200": {"description": "OK", "schema": {"$ref": "#/definitions/Pet"}}},
+        "x-rate-limit": 10
+      }
+    }
+  },
+  "definitions": {
+    "Pet": {"type": "object", "properties": {"name": {"type": "string", "x-order": 1}}, "x-go-type": "github.com/acme/pets.Pet"}
+  },
+  "securityDefinitions": {"key": {"type": "apiKey", "in": "header", "name": "X-Key"}},
+  "x-owner": "pets-team"
+}`
+
+func TestLoadDocument(t *testing.T) {
+	type Owner struct {
+		Name string `json:"name"`
+	}
+
+	g, err := swgen.LoadDocument([]byte(legacySpec))
+	require.NoError(t, err)
+
+	g.SetPathItem(swgen.PathItemInfo{
+		Method:   http.MethodGet,
+		Path:     "/owners",
+		Response: new([]Owner),
+	})
+
+	b, err := g.GenDocument()
+	require.NoError(t, err)
+
+	assertjson.Equal(t, []byte(`{
+	  "swagger":"2.0",
+	  "info":{"title":"Legacy","description":"","termsOfService":"","contact":{"name":""},"license":{"name":""},"version":"1.0"},
+	  "host":"legacy.example.com","basePath":"/api","schemes":["https"],
+	  "paths":{
+		"/owners":{"get":{"summary":"","description":"","responses":{"200":{"description":"OK","schema":{"type":"array","items":{"$ref":"#/definitions/Owner"}}}}}},
+		"/pets/{id}":{
+		  "get":{
+			"summary":"Get pet","description":"",
+			"parameters":[{"type":"integer","name":"id","in":"path","required":true}],
+			"responses":{"200":{"description":"OK","schema":{"$ref":"#/definitions/Pet"}}},
+			"x-rate-limit":10
+		  }
+		}
+	  },
+	  "definitions":{
+		"Owner":{"type":"object","properties":{"name":{"type":"string"}}},
+		"Pet":{"type":"object","properties":{"name":{"type":"string","x-order":1}},"x-go-type":"github.com/acme/pets.Pet"}
+	  },
+	  "securityDefinitions":{"key":{"type":"apiKey","in":"header","name":"X-Key"}},
+	  "x-owner":"pets-team"
+	}`), b, string(b))
+}
+
+func TestLoadDocument_invalid(t *testing.T) {
+	_, err := swgen.LoadDocument([]byte(`{"openapi":"3.0.3"}`))
+	assert.EqualError(t, err, `unsupported document version "", 2.0 expected`)
+
+	_, err = swgen.LoadDocument([]byte(`[]`))
+	assert.Error(t, err)
+}
+
+func TestGenerator_Merge(t *testing.T) {
+	type Pet struct {
+		ID int `json:"id"`
+	}
+
+	g := swgen.NewGenerator()
+	g.SetInfo("Reflected", "", "", "2.0")
+	g.SetHost("api.example.com").SetBasePath("/v2")
+	g.AddSecurityDefinition("key", swgen.SecurityDef{Type: swgen.SecurityAPIKey, In: swgen.APIKeyInQuery, Name: "key"})
+	g.SetPathItem(swgen.PathItemInfo{
+		Method:   http.MethodGet,
+		Path:     "/pets/{id}",
+		Response: new(Pet),
+	})
+
+	legacy := swgen.Document{}
+	require.NoError(t, json.Unmarshal([]byte(legacySpec), &legacy))
+
+	err := g.Merge(legacy)
+
+	var me swgen.MergeError
+
+	require.True(t, errors.As(err, &me))
+	assert.Equal(t, []swgen.MergeConflict{
+		{Kind: "info", Name: "Legacy"},
+		{Kind: "host", Name: "legacy.example.com"},
+		{Kind: "basePath", Name: "/api"},
+		{Kind: "securityDefinition", Name: "key"},
+		{Kind: "definition", Name: "Pet"},
+		{Kind: "path", Name: "GET /pets/{id}"},
+	}, me.Conflicts)
+	assert.EqualError(t, err, "merge conflicts: info Legacy, host legacy.example.com, basePath /api, "+
+		"securityDefinition key, definition Pet, path GET /pets/{id}")
+
+	doc := g.Document()
+	assert.Equal(t, "/v2", doc.BasePath)
+	assert.Equal(t, []string{"https"}, doc.Schemes)
+
+	// Merged schemes are kept.
+	err = g.Merge(swgen.Document{Schemes: []string{"http"}})
+	assert.EqualError(t, err, "merge conflicts: schemes http")
+	assert.Equal(t, []string{"https"}, g.Document().Schemes)
+}
@@ -0,0 +1,144 @@
+package swgen
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultMockStatusHeader is a request header that overrides status code of mock response.
+const DefaultMockStatusHeader = "X-Mock-Status"
+
+// MockHandler serves documented responses of operations registered in Generator.
+//
+// Requests are routed by path templates including gorilla.Mux-style regexps, e.g. "/pets/{id:[0-9]+}",
+// and validated against operation parameters. Invalid request receives 400 Bad Request with ValidationError
+// in JSON body, valid request receives the first documented successful response with explicit or synthesized
+// example in body.
+type MockHandler struct {
+	// StatusHeader is a name of request header with status code of response, default DefaultMockStatusHeader.
+	// Body of overridden response is an example of documented response with such status, if available.
+	StatusHeader string
+
+	g         *Generator
+	validator *RequestValidator
+}
+
+// NewMockHandler creates MockHandler, operations should be registered before first request.
+func NewMockHandler(g *Generator) *MockHandler {
+	return &MockHandler{
+		StatusHeader: DefaultMockStatusHeader,
+		g:            g,
+		validator:    NewRequestValidator(g),
+	}
+}
+
+// ServeHTTP responds with example of documented response.
+func (m *MockHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	op, path, _ := m.validator.FindOperation(r)
+	if op == nil {
+		if path == "" {
+			writeMockError(w, http.StatusNotFound, "operation not found")
+
+			return
+		}
+
+		methods := sortedKeys(m.validator.pathOperations(path))
+		w.Header().Set("Allow", strings.Join(methods, ", "))
+		writeMockError(w, http.StatusMethodNotAllowed, "method not allowed")
+
+		return
+	}
+
+	if err := m.validator.ValidateRequest(r); err != nil {
+		var ve ValidationError
+		if !errors.As(err, &ve) {
+			writeMockError(w, http.StatusInternalServerError, err.Error())
+
+			return
+		}
+
+		writeMockJSON(w, http.StatusBadRequest, mimeJSON, ve)
+
+		return
+	}
+
+	statusCode := mockStatusCode(op)
+
+	if override := r.Header.Get(m.StatusHeader); override != "" {
+		code, err := strconv.Atoi(override)
+		if err != nil || code < 100 || code > 999 {
+			writeMockError(w, http.StatusBadRequest, "invalid "+m.StatusHeader+" header: "+override)
+
+			return
+		}
+
+		statusCode = code
+	}
+
+	resp, found := op.Responses[statusCode]
+	if !found || resp.Schema == nil {
+		w.WriteHeader(statusCode)
+
+		return
+	}
+
+	mime, example := m.example(op, resp)
+	writeMockJSON(w, statusCode, mime, example)
+}
+
+// mockStatusCode returns the lowest documented successful status code, or the lowest documented code.
+func mockStatusCode(op *OperationObj) int {
+	codes := sortedIntKeys(op.Responses)
+
+	for _, code := range codes {
+		if code >= 200 && code < 300 {
+			return code
+		}
+	}
+
+	if len(codes) > 0 {
+		return codes[0]
+	}
+
+	return http.StatusOK
+}
+
+// example returns MIME type and explicit or synthesized example of response, JSON types are preferred.
+func (m *MockHandler) example(op *OperationObj, resp ResponseObj) (string, interface{}) {
+	produces := op.Produces
+	if len(produces) == 0 {
+		produces = []string{mimeJSON}
+	}
+
+	mime := produces[0]
+
+	for _, p := range produces {
+		if strings.Contains(p, "json") {
+			mime = p
+
+			break
+		}
+	}
+
+	if examples, ok := resp.Examples.(map[string]interface{}); ok {
+		if example, ok := examples[mime]; ok {
+			return mime, example
+		}
+	}
+
+	return mime, m.g.ExampleFor(*resp.Schema)
+}
+
+func writeMockError(w http.ResponseWriter, statusCode int, message string) {
+	writeMockJSON(w, statusCode, mimeJSON, map[string]string{"error": message})
+}
+
+func writeMockJSON(w http.ResponseWriter, statusCode int, mime string, v interface{}) {
+	w.Header().Set("Content-Type", mime)
+	w.WriteHeader(statusCode)
+
+	_ = json.NewEncoder(w).Encode(v) //nolint:errchkjson // Error can not be reported to client.
+}
@@ -0,0 +1,151 @@
+package swgen_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/swgen"
+)
+
+type mockPet struct {
+	ID   int64  `json:"id" minimum:"1"`
+	Name string `json:"name" required:"true" minLength:"1"`
+}
+
+type mockPetID struct {
+	ID int64 `path:"id"`
+}
+
+type mockPetRequest struct {
+	Name string `json:"name" required:"true" minLength:"1"`
+}
+
+type mockConflict struct {
+	Error string `json:"error" enum:"duplicate name"`
+}
+
+func mockGenerator() *swgen.Generator {
+	g := swgen.NewGenerator()
+
+	g.SetPathItem(swgen.PathItemInfo{
+		Path:     "/pets/{id:[0-9]+}",
+		Method:   http.MethodGet,
+		Request:  new(mockPetID),
+		Response: new(mockPet),
+	})
+
+	create := swgen.PathItemInfo{
+		Path:                   "/pets",
+		Method:                 http.MethodPost,
+		Request:                new(mockPetRequest),
+		Response:               new(mockPet),
+		SuccessfulResponseCode: http.StatusCreated,
+		ResponseExamples: map[int]interface{}{
+			http.StatusCreated: mockPet{ID: 7, Name: "Rex"},
+		},
+	}
+	create.AddResponse(http.StatusConflict, new(mockConflict))
+	g.SetPathItem(create)
+
+	return g
+}
+
+func serveMock(h http.Handler, method, target, body string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestNewMockHandler(t *testing.T) {
+	g := mockGenerator()
+	h := swgen.NewMockHandler(g)
+	v := swgen.NewResponseValidator(g)
+
+	rec := serveMock(h, http.MethodGet, "/pets/12", "", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.NoError(t, v.ValidateRecorder(httptest.NewRequest(http.MethodGet, "/pets/12", nil), rec))
+
+	rec = serveMock(h, http.MethodGet, "/pets/abc", "", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code, "gorilla-style pattern does not match")
+	assertjson.Equal(t, []byte(`{"error":"operation not found"}`), rec.Body.Bytes())
+
+	rec = serveMock(h, http.MethodDelete, "/pets/12", "", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET", rec.Header().Get("Allow"))
+
+	rec = serveMock(h, http.MethodPost, "/pets", `{"name":""}`, nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assertjson.Equal(t, []byte(`{
+	  "error":"invalid request",
+	  "errors":[{"in":"body","name":"/name","message":"length must be >= 1, but got 0"}]
+	}`), rec.Body.Bytes())
+
+	rec = serveMock(h, http.MethodPost, "/pets", `{"name":"Rex"}`, nil)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assertjson.Equal(t, []byte(`{"id":7,"name":"Rex"}`), rec.Body.Bytes())
+
+	rec = serveMock(h, http.MethodPost, "/pets", `{"name":"Rex"}`, http.Header{"X-Mock-Status": []string{"409"}})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assertjson.Equal(t, []byte(`{"error":"duplicate name"}`), rec.Body.Bytes())
+
+	rec = serveMock(h, http.MethodPost, "/pets", `{"name":"Rex"}`, http.Header{"X-Mock-Status": []string{"503"}})
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	rec = serveMock(h, http.MethodPost, "/pets", `{"name":"Rex"}`, http.Header{"X-Mock-Status": []string{"teapot"}})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMockHandler_StatusHeader(t *testing.T) {
+	h := swgen.NewMockHandler(mockGenerator())
+	h.StatusHeader = "X-Status"
+
+	rec := serveMock(h, http.MethodPost, "/pets", `{"name":"Rex"}`, http.Header{"X-Status": []string{"409"}})
+	require.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = serveMock(h, http.MethodPost, "/pets", `{"name":"Rex"}`, http.Header{"X-Mock-Status": []string{"409"}})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestMockHandler_pathsChanged(t *testing.T) {
+	g := mockGenerator()
+	h := swgen.NewMockHandler(g)
+
+	assert.Equal(t, http.StatusNotFound, serveMock(h, http.MethodGet, "/owners", "", nil).Code)
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i := 0; i < 10; i++ {
+			serveMock(h, http.MethodGet, "/pets/1", "", nil)
+		}
+	}()
+
+	g.SetPathItem(swgen.PathItemInfo{Path: "/owners", Method: http.MethodGet, Response: new([]mockPet)})
+	<-done
+
+	// Router is rebuilt after registration of new path.
+	assert.Equal(t, http.StatusOK, serveMock(h, http.MethodGet, "/owners", "", nil).Code)
+
+	rec := serveMock(h, http.MethodDelete, "/owners", "", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET", rec.Header().Get("Allow"))
+}
@@ -0,0 +1,391 @@
+package swgen
+
+import (
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+const (
+	// xContentType lists allowed content types of multipart form part.
+	xContentType = "x-content-type"
+	// xMaxSize limits size of uploaded file in bytes.
+	xMaxSize = "x-max-size"
+	// xMultiple marks Swagger 2.0 file parameter that accepts multiple files.
+	xMultiple = "x-multiple"
+)
+
+// isFileParam checks if parameter is a file or an array of files.
+func isFileParam(p ParamObj) bool {
+	return p.Type == "file" || isFileArray(p)
+}
+
+// isFileArray checks if parameter is an array of files.
+func isFileArray(p ParamObj) bool {
+	return p.Type == "array" && p.Items != nil && p.Items.Type == "file"
+}
+
+// swagger2FileArray renders array of files as file parameter with "x-multiple", Swagger 2.0 has no file items.
+func swagger2FileArray(p ParamObj) ParamObj {
+	data := make(map[string]interface{}, len(p.data)+1)
+	for k, v := range p.data {
+		data[k] = v
+	}
+
+	data[xMultiple] = true
+	p.data = data
+	p.Type = "file"
+	p.Items = nil
+	p.CollectionFormat = ""
+
+	return p
+}
+
+// readPartTags reads `contentType` and `maxSize` tags of multipart form part.
+//
+// Structure part is sent as JSON unless `contentType` tag tells otherwise.
+func readPartTags(tag reflect.StructTag, param *ParamObj) []error {
+	var errs []error
+
+	contentType := tag.Get("contentType")
+	if contentType == "" && param.partSchema != nil {
+		contentType = mimeJSON
+	}
+
+	if contentType != "" {
+		var contentTypes []string
+
+		for _, ct := range strings.Split(contentType, ",") {
+			ct = strings.TrimSpace(ct)
+
+			if _, _, err := mime.ParseMediaType(ct); err != nil {
+				errs = append(errs, FieldError{Tag: "contentType", Value: contentType, Err: err})
+
+				continue
+			}
+
+			contentTypes = append(contentTypes, ct)
+		}
+
+		param.AddExtendedField(xContentType, contentTypes)
+	}
+
+	if value, ok := tag.Lookup("maxSize"); ok {
+		maxSize, err := strconv.ParseInt(value, 10, 64)
+
+		switch {
+		case err != nil:
+			errs = append(errs, FieldError{Tag: "maxSize", Value: value, Err: err})
+		case !isFileParam(*param):
+			errs = append(errs, FieldError{Tag: "maxSize", Value: value, Err: errUnsupportedParamType})
+		default:
+			param.AddExtendedField(xMaxSize, maxSize)
+		}
+	}
+
+	return errs
+}
+
+// partContentTypes returns allowed content types of multipart form part.
+func partContentTypes(data map[string]interface{}) []string {
+	switch v := data[xContentType].(type) {
+	case []string:
+		return v
+	case []interface{}: // Decoded from JSON.
+		res := make([]string, 0, len(v))
+
+		for _, ct := range v {
+			if s, ok := ct.(string); ok {
+				res = append(res, s)
+			}
+		}
+
+		return res
+	}
+
+	return nil
+}
+
+// partMaxSize returns size limit of uploaded file, 0 if unlimited.
+func partMaxSize(data map[string]interface{}) int64 {
+	switch v := data[xMaxSize].(type) {
+	case int64:
+		return v
+	case float64: // Decoded from JSON.
+		return int64(v)
+	}
+
+	return 0
+}
+
+// contentTypeAllowed checks content type against a list of allowed ones, e.g. "image/png" or "image/*".
+func contentTypeAllowed(contentType string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, a := range allowed {
+		if a == "*/*" || strings.EqualFold(a, mt) ||
+			(strings.HasSuffix(a, "/*") && strings.HasPrefix(mt, strings.TrimSuffix(a, "*"))) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fileViolations checks uploaded files against parameter constraints.
+func fileViolations(p ParamObj, files []*multipart.FileHeader) []Violation {
+	var violations []Violation
+
+	if p.Type == "file" && len(files) > 1 {
+		violations = append(violations, Violation{In: p.In, Name: p.Name, Message: "multiple files are not allowed"})
+	}
+
+	if p.MaxItems != nil && int64(len(files)) > *p.MaxItems {
+		violations = append(violations, Violation{In: p.In, Name: p.Name,
+			Message: fmt.Sprintf("too many files, at most %d allowed", *p.MaxItems)})
+	}
+
+	if p.MinItems != nil && int64(len(files)) < *p.MinItems {
+		violations = append(violations, Violation{In: p.In, Name: p.Name,
+			Message: fmt.Sprintf("not enough files, at least %d required", *p.MinItems)})
+	}
+
+	allowed := partContentTypes(p.data)
+	maxSize := partMaxSize(p.data)
+
+	for _, f := range files {
+		if maxSize > 0 && f.Size > maxSize {
+			violations = append(violations, Violation{In: p.In, Name: p.Name,
+				Message: fmt.Sprintf("file %q is larger than %d bytes", f.Filename, maxSize)})
+		}
+
+		if ct := f.Header.Get("Content-Type"); !contentTypeAllowed(ct, allowed) {
+			violations = append(violations, Violation{In: p.In, Name: p.Name,
+				Message: fmt.Sprintf("file %q has unexpected content type %q", f.Filename, ct)})
+		}
+	}
+
+	return violations
+}
+
+// oas3Encoding moves allowed content types from properties of multipart form schema to encoding.
+func oas3Encoding(form *SchemaObj) map[string]OAS3EncodingObj {
+	var encoding map[string]OAS3EncodingObj
+
+	for name, prop := range form.Properties {
+		contentTypes := partContentTypes(prop.data)
+		if len(contentTypes) == 0 {
+			continue
+		}
+
+		if encoding == nil {
+			encoding = make(map[string]OAS3EncodingObj)
+		}
+
+		encoding[name] = OAS3EncodingObj{ContentType: strings.Join(contentTypes, ", ")}
+
+		delete(prop.data, xContentType)
+		form.Properties[name] = prop
+	}
+
+	return encoding
+}
+
+// setOpenAPIEncoding adds allowed content types, file parts and their size limits to multipart request body
+// of OpenAPI 3.0 operation.
+func setOpenAPIEncoding(op *openapi3.Operation, request interface{}, r *openapi3.Reflector) {
+	if op.RequestBody == nil || op.RequestBody.RequestBody == nil {
+		return
+	}
+
+	mt, ok := op.RequestBody.RequestBody.Content[mimeMultipartForm]
+	if !ok {
+		return
+	}
+
+	encoding := partEncoding(reflect.TypeOf(request))
+
+	for _, name := range sortedKeys(encoding) {
+		e := openapi3.Encoding{}
+		e.WithContentType(encoding[name])
+		mt.WithEncodingItem(name, e)
+	}
+
+	op.RequestBody.RequestBody.Content[mimeMultipartForm] = mt
+
+	form := mt.Schema
+	if form != nil && form.SchemaReference != nil && strings.HasPrefix(form.SchemaReference.Ref, refComponentsPrefix) {
+		form = nil
+
+		if r.Spec.Components != nil && r.Spec.Components.Schemas != nil {
+			if sr, ok := r.Spec.Components.Schemas.MapOfSchemaOrRefValues[strings.TrimPrefix(
+				mt.Schema.SchemaReference.Ref, refComponentsPrefix)]; ok {
+				form = &sr
+			}
+		}
+	}
+
+	if form == nil || form.Schema == nil {
+		return
+	}
+
+	// Reflector only reads `formData` tag, so parts declared with `file` tag are added here.
+	for name, part := range fileParts(reflect.TypeOf(request)) {
+		prop, ok := form.Schema.Properties[name]
+		if !ok && part.file {
+			binary := (&openapi3.Schema{}).WithType(openapi3.SchemaTypeString).WithFormat("binary")
+			prop = openapi3.SchemaOrRef{Schema: binary}
+
+			if part.multiple {
+				array := (&openapi3.Schema{}).WithType(openapi3.SchemaTypeArray).WithItems(prop)
+				prop = openapi3.SchemaOrRef{Schema: array}
+			}
+
+			form.Schema.WithPropertiesItem(name, prop)
+		}
+
+		if part.maxSize > 0 && prop.Schema != nil {
+			prop.Schema.WithMapOfAnythingItem(xMaxSize, part.maxSize)
+		}
+	}
+}
+
+// partName returns name of multipart form part declared with `formData` or `file` tag.
+func partName(field reflect.StructField) string {
+	name := field.Tag.Get("formData")
+	if name == "" {
+		name = field.Tag.Get("file")
+	}
+
+	name = strings.Split(name, ",")[0]
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
+
+// filePart describes multipart form part of uploaded files.
+type filePart struct {
+	file     bool // Declared with `file` tag.
+	multiple bool
+	maxSize  int64
+}
+
+// fileParts maps names of multipart form parts of uploaded files to their descriptions.
+func fileParts(t reflect.Type) map[string]filePart {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	res := map[string]filePart{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous {
+			for name, part := range fileParts(field.Type) {
+				res[name] = part
+			}
+
+			continue
+		}
+
+		name := partName(field)
+		if name == "" {
+			continue
+		}
+
+		ft := field.Type
+		for ft.Kind() == reflect.Ptr || ft.Kind() == reflect.Slice {
+			ft = ft.Elem()
+		}
+
+		if ft != reflect.TypeOf(multipart.FileHeader{}) {
+			continue
+		}
+
+		part := filePart{
+			file:     field.Tag.Get("formData") == "",
+			multiple: field.Type.Kind() == reflect.Slice,
+		}
+
+		// Invalid values are reported by readPartTags.
+		if size, err := strconv.ParseInt(field.Tag.Get("maxSize"), 10, 64); err == nil {
+			part.maxSize = size
+		}
+
+		res[name] = part
+	}
+
+	return res
+}
+
+// partEncoding maps names of multipart form parts to their content types declared with `contentType` tag.
+func partEncoding(t reflect.Type) map[string]string {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	res := map[string]string{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous {
+			for name, ct := range partEncoding(field.Type) {
+				res[name] = ct
+			}
+
+			continue
+		}
+
+		name := partName(field)
+		if name == "" {
+			continue
+		}
+
+		ct := field.Tag.Get("contentType")
+
+		ft := field.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+
+		if ct == "" && ft.Kind() == reflect.Struct && ft != reflect.TypeOf(multipart.FileHeader{}) &&
+			!reflect.PtrTo(ft).Implements(typeOfTextUnmarshaler) && ft != typeOfTime {
+			ct = mimeJSON
+		}
+
+		if ct != "" {
+			contentTypes := strings.Split(ct, ",")
+			for i, ct := range contentTypes {
+				contentTypes[i] = strings.TrimSpace(ct)
+			}
+
+			res[name] = strings.Join(contentTypes, ", ")
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,198 @@
+package swgen_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/swgen"
+)
+
+type albumMeta struct {
+	Title string `json:"title" minLength:"1"`
+}
+
+type albumUpload struct {
+	Album  string                  `formData:"album" required:"true"`
+	Meta   albumMeta               `formData:"meta"`
+	Cover  *multipart.FileHeader   `file:"cover" contentType:"image/png, image/jpeg" maxSize:"8"`
+	Photos []*multipart.FileHeader `formData:"photos" contentType:"image/*" maxItems:"2"`
+}
+
+func TestGenerator_multipart(t *testing.T) {
+	reflector := openapi3.Reflector{}
+
+	g := swgen.NewGenerator()
+	g.SetOAS3Proxy(&reflector)
+	g.SetPathItem(swgen.PathItemInfo{Path: "/albums", Method: http.MethodPost, Request: new(albumUpload)})
+
+	data, err := g.GenDocument()
+	require.NoError(t, err)
+
+	var doc swgen.Document
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assertjson.EqualMarshal(t, []byte(`[
+	  {"type":"string","name":"album","in":"formData","required":true},
+	  {"type":"string","name":"meta","in":"formData","x-content-type":["application/json"]},
+	  {"type":"file","name":"cover","in":"formData","x-content-type":["image/png","image/jpeg"],"x-max-size":8},
+	  {
+	    "type":"file","name":"photos","in":"formData","maxItems":2,"x-content-type":["image/*"],"x-multiple":true
+	  }
+	]`), doc.Paths["/albums"].Post.Parameters)
+
+	oas3 := g.DocumentOAS3()
+	assertjson.EqualMarshal(t, []byte(`{
+	  "content":{
+	    "multipart/form-data":{
+	      "schema":{
+	        "required":["album"],"type":"object",
+	        "properties":{
+	          "album":{"type":"string"},
+	          "cover":{"type":"string","format":"binary","x-max-size":8},
+	          "meta":{"$ref":"#/components/schemas/albumMeta"},
+	          "photos":{"type":"array","items":{"type":"string","format":"binary"},"maxItems":2}
+	        }
+	      },
+	      "encoding":{
+	        "cover":{"contentType":"image/png, image/jpeg"},
+	        "meta":{"contentType":"application/json"},
+	        "photos":{"contentType":"image/*"}
+	      }
+	    }
+	  }
+	}`), oas3.Paths["/albums"].Post.RequestBody)
+
+	proxied := reflector.Spec.Paths.MapOfPathItemValues["/albums"].MapOfOperationValues["post"]
+	assertjson.EqualMarshal(t, []byte(`{
+	  "cover":{"contentType":"image/png, image/jpeg"},
+	  "meta":{"contentType":"application/json"},
+	  "photos":{"contentType":"image/*"}
+	}`), proxied.RequestBody.RequestBody.Content["multipart/form-data"].Encoding)
+
+	form := reflector.Spec.Components.Schemas.MapOfSchemaOrRefValues["FormDataSwgenTestAlbumUpload"]
+	assertjson.EqualMarshal(t, []byte(`{"type":"string","format":"binary","x-max-size":8}`),
+		form.Schema.Properties["cover"])
+
+	type part struct {
+		name, filename, contentType, body string
+	}
+
+	upload := func(parts ...part) *http.Request {
+		var buf bytes.Buffer
+
+		w := multipart.NewWriter(&buf)
+
+		for _, p := range parts {
+			h := textproto.MIMEHeader{}
+			h.Set("Content-Disposition", `form-data; name="`+p.name+`"; filename="`+p.filename+`"`)
+
+			if p.filename == "" {
+				h.Set("Content-Disposition", `form-data; name="`+p.name+`"`)
+			}
+
+			if p.contentType != "" {
+				h.Set("Content-Type", p.contentType)
+			}
+
+			pw, err := w.CreatePart(h)
+			require.NoError(t, err)
+
+			_, err = pw.Write([]byte(p.body))
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, w.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/albums", &buf)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+
+		return req
+	}
+
+	v := swgen.NewRequestValidator(g)
+
+	assert.NoError(t, v.ValidateRequest(upload(
+		part{name: "album", body: "summer"},
+		part{name: "meta", body: `{"title":"Summer"}`},
+		part{name: "cover", filename: "cover.png", contentType: "image/png", body: "png"},
+		part{name: "photos", filename: "1.jpg", contentType: "image/jpeg", body: "jpg"},
+		part{name: "photos", filename: "2.gif", contentType: "image/gif", body: "gif"},
+	)))
+
+	err = v.ValidateRequest(upload(
+		part{name: "album", body: "summer"},
+		part{name: "meta", body: `{"title":""}`},
+		part{name: "cover", filename: "cover.pdf", contentType: "application/pdf", body: "too large pdf"},
+		part{name: "photos", filename: "1.jpg", contentType: "image/jpeg", body: "jpg"},
+		part{name: "photos", filename: "2.jpg", contentType: "image/jpeg", body: "jpg"},
+		part{name: "photos", filename: "3.jpg", contentType: "image/jpeg", body: "jpg"},
+	))
+	require.Error(t, err)
+	assertjson.EqualMarshal(t, []byte(`{
+	  "error":"invalid request",
+	  "errors":[
+	    {"in":"formData","name":"meta/title","message":"length must be >= 1, but got 0"},
+	    {"in":"formData","name":"cover","message":"file \"cover.pdf\" is larger than 8 bytes"},
+	    {"in":"formData","name":"cover","message":"file \"cover.pdf\" has unexpected content type \"application/pdf\""},
+	    {"in":"formData","name":"photos","message":"too many files, at most 2 allowed"}
+	  ]
+	}`), err)
+}
+
+func TestGenerator_multipart_conflict(t *testing.T) {
+	type conflictingUpload struct {
+		Photo  *multipart.FileHeader   `file:"photo"`
+		Photos []*multipart.FileHeader `file:"photo"`
+	}
+
+	g := swgen.NewGenerator()
+
+	_, err := g.TrySetPathItem(swgen.PathItemInfo{Path: "/photos", Method: http.MethodPost, Request: new(conflictingUpload)})
+	assert.EqualError(t, err, "failed to reflect POST /photos: "+
+		"github.com/swaggest/swgen_test.conflictingUpload.Photos: photo: conflicting file parameters with the same name")
+}
+
+func TestGenerator_multipart_urlEncoded(t *testing.T) {
+	type avatarUpload struct {
+		Avatar *multipart.FileHeader `file:"avatar" required:"true"`
+	}
+
+	g := swgen.NewGenerator()
+	g.SetPathItem(swgen.PathItemInfo{Path: "/avatar", Method: http.MethodPost, Request: new(avatarUpload)})
+
+	v := swgen.NewRequestValidator(g)
+
+	post := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/avatar", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		return req
+	}
+
+	err := v.ValidateRequest(post("avatar=me.png"))
+	require.Error(t, err)
+	assert.Equal(t, "invalid request: formData avatar: file must be sent in multipart/form-data request", err.Error())
+
+	err = v.ValidateRequest(post("other=1"))
+	require.Error(t, err)
+	assert.Equal(t, "invalid request: formData avatar: missing value", err.Error())
+}
+
+func TestGenerator_multipart_collectionFormat(t *testing.T) {
+	g := swgen.NewGenerator()
+	g.SetDefaultCollectionFormat("csv")
+
+	op := g.SetPathItem(swgen.PathItemInfo{Path: "/albums", Method: http.MethodPost, Request: new(albumUpload)})
+	require.Len(t, op.Parameters, 4)
+	assert.Equal(t, "photos", op.Parameters[3].Name)
+	assert.Equal(t, "multi", op.Parameters[3].CollectionFormat)
+}
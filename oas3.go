@@ -0,0 +1,698 @@
+package swgen
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	refComponentsPrefix = "#/components/schemas/"
+	oas3Version         = "3.0.3"
+	mimeJSON            = "application/json"
+	mimeFormURLEncoded  = "application/x-www-form-urlencoded"
+	mimeMultipartForm   = "multipart/form-data"
+	bearerDescription   = "Should be in form: 'Bearer <token_value>'"
+)
+
+// OAS3Document represents OpenAPI 3.0 document, see https://swagger.io/specification/.
+type OAS3Document struct {
+	OpenAPI    string                  `json:"openapi"`
+	Info       InfoObj                 `json:"info"`
+	Servers    []OAS3ServerObj         `json:"servers,omitempty"`
+	Paths      map[string]OAS3PathItem `json:"paths"`
+	Components OAS3ComponentsObj       `json:"components,omitempty"`
+	additionalData
+}
+
+// MarshalJSON marshal OAS3Document with additionalData inlined.
+func (d OAS3Document) MarshalJSON() ([]byte, error) {
+	type i OAS3Document
+
+	return d.marshalJSONWithStruct(i(d))
+}
+
+// OAS3ServerObj describes a server.
+type OAS3ServerObj struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// OAS3ComponentsObj holds reusable objects.
+type OAS3ComponentsObj struct {
+	Schemas         map[string]SchemaObj             `json:"schemas,omitempty"`
+	SecuritySchemes map[string]OAS3SecuritySchemeObj `json:"securitySchemes,omitempty"`
+}
+
+// OAS3SecuritySchemeObj defines a security scheme.
+type OAS3SecuritySchemeObj struct {
+	Type         string          `json:"type"` // "apiKey", "http", "oauth2" or "openIdConnect".
+	Description  string          `json:"description,omitempty"`
+	Name         string          `json:"name,omitempty"`
+	In           string          `json:"in,omitempty"`
+	Scheme       string          `json:"scheme,omitempty"`
+	BearerFormat string          `json:"bearerFormat,omitempty"`
+	Flows        *OAS3OAuthFlows `json:"flows,omitempty"`
+}
+
+// OAS3OAuthFlows allows configuration of the supported OAuth flows.
+type OAS3OAuthFlows struct {
+	Implicit          *OAS3OAuthFlowObj `json:"implicit,omitempty"`
+	Password          *OAS3OAuthFlowObj `json:"password,omitempty"`
+	ClientCredentials *OAS3OAuthFlowObj `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OAS3OAuthFlowObj `json:"authorizationCode,omitempty"`
+}
+
+// OAS3OAuthFlowObj holds configuration details for a supported OAuth flow.
+type OAS3OAuthFlowObj struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"`
+}
+
+// OAS3PathItem describes the operations available on a single path.
+type OAS3PathItem struct {
+	Get     *OAS3OperationObj `json:"get,omitempty"`
+	Put     *OAS3OperationObj `json:"put,omitempty"`
+	Post    *OAS3OperationObj `json:"post,omitempty"`
+	Delete  *OAS3OperationObj `json:"delete,omitempty"`
+	Options *OAS3OperationObj `json:"options,omitempty"`
+	Head    *OAS3OperationObj `json:"head,omitempty"`
+	Patch   *OAS3OperationObj `json:"patch,omitempty"`
+}
+
+// OAS3OperationObj describes a single API operation on a path.
+type OAS3OperationObj struct {
+	Tags        []string                `json:"tags,omitempty"`
+	Summary     string                  `json:"summary,omitempty"`
+	Description string                  `json:"description,omitempty"`
+	Parameters  []OAS3ParamObj          `json:"parameters,omitempty"`
+	RequestBody *OAS3RequestBodyObj     `json:"requestBody,omitempty"`
+	Responses   map[int]OAS3ResponseObj `json:"responses"`
+	Security    []map[string][]string   `json:"security,omitempty"`
+	Deprecated  bool                    `json:"deprecated,omitempty"`
+	additionalData
+}
+
+// MarshalJSON marshal OAS3OperationObj with additionalData inlined.
+func (o OAS3OperationObj) MarshalJSON() ([]byte, error) {
+	type i OAS3OperationObj
+
+	return o.marshalJSONWithStruct(i(o))
+}
+
+// OAS3ParamObj describes a single operation parameter.
+type OAS3ParamObj struct {
+	Name        string     `json:"name"`
+	In          string     `json:"in"` // Possible values are "query", "header", "path" or "cookie".
+	Description string     `json:"description,omitempty"`
+	Required    bool       `json:"required,omitempty"`
+	Deprecated  bool       `json:"deprecated,omitempty"`
+	Style       string     `json:"style,omitempty"`
+	Explode     *bool      `json:"explode,omitempty"`
+	Schema      *SchemaObj `json:"schema,omitempty"`
+	additionalData
+}
+
+// MarshalJSON marshal OAS3ParamObj with additionalData inlined.
+func (o OAS3ParamObj) MarshalJSON() ([]byte, error) {
+	type i OAS3ParamObj
+
+	return o.marshalJSONWithStruct(i(o))
+}
+
+// OAS3RequestBodyObj describes a single request body.
+type OAS3RequestBodyObj struct {
+	Description string                      `json:"description,omitempty"`
+	Content     map[string]OAS3MediaTypeObj `json:"content"`
+	Required    bool                        `json:"required,omitempty"`
+}
+
+// OAS3MediaTypeObj provides schema and examples for the media type.
+type OAS3MediaTypeObj struct {
+	Schema   *SchemaObj                 `json:"schema,omitempty"`
+	Example  interface{}                `json:"example,omitempty"`
+	Examples map[string]OAS3ExampleObj  `json:"examples,omitempty"`
+	Encoding map[string]OAS3EncodingObj `json:"encoding,omitempty"`
+}
+
+// OAS3ExampleObj holds a named example.
+type OAS3ExampleObj struct {
+	Summary string      `json:"summary,omitempty"`
+	Value   interface{} `json:"value,omitempty"`
+}
+
+// OAS3EncodingObj is a single encoding definition applied to a single schema property.
+type OAS3EncodingObj struct {
+	ContentType string `json:"contentType,omitempty"`
+	Style       string `json:"style,omitempty"`
+	Explode     *bool  `json:"explode,omitempty"`
+}
+
+// OAS3ResponseObj describes a single response from an API Operation.
+type OAS3ResponseObj struct {
+	Description string                      `json:"description"`
+	Headers     map[string]OAS3HeaderObj    `json:"headers,omitempty"`
+	Content     map[string]OAS3MediaTypeObj `json:"content,omitempty"`
+}
+
+// OAS3HeaderObj describes a single response header.
+type OAS3HeaderObj struct {
+	Description string     `json:"description,omitempty"`
+	Required    bool       `json:"required,omitempty"`
+	Schema      *SchemaObj `json:"schema,omitempty"`
+}
+
+// GenDocumentOAS3 returns OpenAPI 3.0 document specification in JSON string (in []byte).
+//
+// Document is built from the same paths and definitions as Swagger 2.0 document.
+func (g *Generator) GenDocumentOAS3() ([]byte, error) {
+	doc := g.DocumentOAS3()
+
+	g.mu.Lock()
+	indent := g.indentJSON
+	g.mu.Unlock()
+
+	if indent {
+		return json.MarshalIndent(doc, "", "  ")
+	}
+
+	return json.Marshal(doc)
+}
+
+// DocumentOAS3 returns OpenAPI 3.0 document built from registered paths and definitions.
+func (g *Generator) DocumentOAS3() OAS3Document {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.parseDefInQueue()
+
+	doc := OAS3Document{
+		OpenAPI: oas3Version,
+		Info:    g.doc.Info,
+		Paths:   make(map[string]OAS3PathItem, len(g.paths)),
+	}
+
+	doc.Servers = g.oas3Servers()
+
+	if len(g.doc.data) > 0 {
+		for k, v := range g.doc.data {
+			doc.AddExtendedField(k, v)
+		}
+	}
+
+	if defs := g.definitions.GenDefinitions(); len(defs) > 0 {
+		doc.Components.Schemas = make(map[string]SchemaObj, len(defs))
+
+		for name, def := range defs {
+			doc.Components.Schemas[name] = oas3Schema(def)
+		}
+	}
+
+	if len(g.doc.SecurityDefinitions) > 0 {
+		doc.Components.SecuritySchemes = make(map[string]OAS3SecuritySchemeObj, len(g.doc.SecurityDefinitions))
+
+		for name, def := range g.doc.SecurityDefinitions {
+			doc.Components.SecuritySchemes[name] = oas3SecurityScheme(def)
+		}
+	}
+
+	for path, item := range g.paths {
+		pi := OAS3PathItem{}
+
+		for method, op := range item.Map() {
+			o := oas3Operation(op)
+
+			switch method {
+			case http.MethodGet:
+				pi.Get = o
+			case http.MethodPut:
+				pi.Put = o
+			case http.MethodPost:
+				pi.Post = o
+			case http.MethodDelete:
+				pi.Delete = o
+			case http.MethodOptions:
+				pi.Options = o
+			case http.MethodHead:
+				pi.Head = o
+			case http.MethodPatch:
+				pi.Patch = o
+			}
+		}
+
+		doc.Paths[path] = pi
+	}
+
+	return doc
+}
+
+func (g *Generator) oas3Servers() []OAS3ServerObj {
+	if g.host == "" {
+		return []OAS3ServerObj{{URL: g.doc.BasePath}}
+	}
+
+	servers := make([]OAS3ServerObj, 0, len(g.doc.Schemes))
+
+	for _, scheme := range g.doc.Schemes {
+		servers = append(servers, OAS3ServerObj{URL: scheme + "://" + g.host + g.doc.BasePath})
+	}
+
+	if len(servers) == 0 {
+		servers = append(servers, OAS3ServerObj{URL: "http://" + g.host + g.doc.BasePath})
+	}
+
+	return servers
+}
+
+func oas3SecurityScheme(def SecurityDef) OAS3SecuritySchemeObj {
+	s := OAS3SecuritySchemeObj{
+		Description: def.Description,
+	}
+
+	switch {
+	case def.isBearer:
+		if s.Description == bearerDescription {
+			s.Description = ""
+		}
+
+		s.Type = "http"
+		s.Scheme = "bearer"
+		s.BearerFormat = def.BearerFormat
+	case def.Type == SecurityBasicAuth:
+		s.Type = "http"
+		s.Scheme = "basic"
+	case def.Type == SecurityAPIKey:
+		s.Type = "apiKey"
+		s.Name = def.Name
+		s.In = string(def.In)
+	case def.Type == SecurityOAuth2:
+		s.Type = "oauth2"
+		s.Flows = &OAS3OAuthFlows{}
+
+		flow := &OAS3OAuthFlowObj{
+			AuthorizationURL: def.AuthorizationURL,
+			TokenURL:         def.TokenURL,
+			Scopes:           def.Scopes,
+		}
+
+		if flow.Scopes == nil {
+			flow.Scopes = map[string]string{}
+		}
+
+		switch def.Flow {
+		case Oauth2Implicit:
+			s.Flows.Implicit = flow
+		case Oauth2Password:
+			s.Flows.Password = flow
+		case Oauth2Application:
+			s.Flows.ClientCredentials = flow
+		case Oauth2AccessCode:
+			s.Flows.AuthorizationCode = flow
+		}
+	}
+
+	return s
+}
+
+func oas3Operation(op *OperationObj) *OAS3OperationObj {
+	o := OAS3OperationObj{
+		Tags:        op.Tags,
+		Summary:     op.Summary,
+		Description: op.Description,
+		Deprecated:  op.Deprecated,
+		Responses:   make(map[int]OAS3ResponseObj, len(op.Responses)),
+	}
+
+	if len(op.Security) > 0 {
+		o.Security = op.Security
+	}
+
+	for k, v := range op.data {
+		o.AddExtendedField(k, v)
+	}
+
+	consumes := op.Consumes
+	if len(consumes) == 0 {
+		consumes = []string{mimeJSON}
+	}
+
+	for _, p := range op.Parameters {
+		switch p.In {
+		case "body":
+			if p.Schema == nil {
+				continue
+			}
+
+			s := oas3Schema(*p.Schema)
+			o.RequestBody = &OAS3RequestBodyObj{
+				Description: p.Description,
+				Required:    p.Required,
+				Content:     make(map[string]OAS3MediaTypeObj, len(consumes)),
+			}
+
+			examples := oas3Examples(p)
+
+			for _, mime := range consumes {
+				if _, ok := op.requestContent[mime]; ok {
+					continue
+				}
+
+				o.RequestBody.Content[mime] = OAS3MediaTypeObj{Schema: &s, Examples: examples}
+			}
+		case "formData":
+			continue
+		default:
+			o.Parameters = append(o.Parameters, oas3Param(p))
+		}
+	}
+
+	if form, multipart := formSchema(op.Parameters); form != nil && !hasFormContent(op.requestContent) {
+		mime := mimeFormURLEncoded
+		if multipart {
+			mime = mimeMultipartForm
+		}
+
+		s := oas3Schema(*form)
+
+		if o.RequestBody == nil {
+			o.RequestBody = &OAS3RequestBodyObj{Content: map[string]OAS3MediaTypeObj{}}
+		}
+
+		encoding := oas3Encoding(&s)
+		if mime == mimeFormURLEncoded {
+			encoding = oas3FormEncoding(encoding, op.Parameters)
+		}
+
+		o.RequestBody.Content[mime] = OAS3MediaTypeObj{Schema: &s, Encoding: encoding}
+	}
+
+	for _, mime := range sortedKeys(op.requestContent) {
+		s := oas3Schema(*op.requestContent[mime])
+
+		if o.RequestBody == nil {
+			o.RequestBody = &OAS3RequestBodyObj{Required: true, Content: map[string]OAS3MediaTypeObj{}}
+		}
+
+		o.RequestBody.Content[mime] = OAS3MediaTypeObj{Schema: &s, Encoding: oas3Encoding(&s)}
+	}
+
+	produces := op.Produces
+	if len(produces) == 0 {
+		produces = []string{mimeJSON}
+	}
+
+	for code, r := range op.Responses {
+		resp := OAS3ResponseObj{Description: r.Description}
+
+		for name, h := range r.Headers {
+			if resp.Headers == nil {
+				resp.Headers = make(map[string]OAS3HeaderObj, len(r.Headers))
+			}
+
+			s := oas3Schema(paramSchema(ParamObj{CommonFields: h.CommonFields, Items: h.Items}))
+			s.Description = ""
+			resp.Headers[name] = OAS3HeaderObj{Description: h.Description, Schema: &s}
+		}
+
+		examples, _ := r.Examples.(map[string]interface{})
+
+		if r.Schema != nil || len(r.content) > 0 {
+			resp.Content = make(map[string]OAS3MediaTypeObj, len(produces)+len(r.content))
+		}
+
+		if r.Schema != nil {
+			s := oas3Schema(*r.Schema)
+
+			for _, mime := range produces {
+				if _, ok := r.content[mime]; ok {
+					continue
+				}
+
+				resp.Content[mime] = OAS3MediaTypeObj{Schema: &s, Example: examples[mime]}
+			}
+		}
+
+		for mime, cs := range r.content {
+			s := oas3Schema(*cs)
+			resp.Content[mime] = OAS3MediaTypeObj{Schema: &s, Example: examples[mime]}
+		}
+
+		o.Responses[code] = resp
+	}
+
+	return &o
+}
+
+// formSchema collects "formData" parameters into object schema,
+// multipart reports files or parts with content type that need "multipart/form-data".
+func formSchema(params []ParamObj) (form *SchemaObj, multipart bool) {
+	for _, p := range params {
+		if p.In != "formData" {
+			continue
+		}
+
+		if form == nil {
+			form = NewSchemaObj("object", "")
+			form.Properties = map[string]SchemaObj{}
+		}
+
+		prop := paramSchema(p)
+		if p.partSchema != nil {
+			prop = *p.partSchema
+		}
+
+		prop.data = nil
+
+		for _, k := range []string{xContentType, xMaxSize} {
+			if v, ok := p.data[k]; ok {
+				prop.AddExtendedField(k, v)
+			}
+		}
+
+		if isFileParam(p) || len(prop.data) > 0 {
+			multipart = true
+		}
+
+		form.Properties[p.Name] = prop
+
+		if p.Required {
+			form.Required = append(form.Required, p.Name)
+		}
+	}
+
+	if form != nil {
+		sort.Strings(form.Required)
+	}
+
+	return form, multipart
+}
+
+func hasFormContent(content map[string]*SchemaObj) bool {
+	for mime := range content {
+		if isFormMIME(mime) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// oas3Examples converts "x-examples" of body parameter to named examples.
+func oas3Examples(p ParamObj) map[string]OAS3ExampleObj {
+	values, _ := p.data[xExamples].(map[string]interface{})
+	if len(values) == 0 {
+		return nil
+	}
+
+	examples := make(map[string]OAS3ExampleObj, len(values))
+	for name, value := range values {
+		examples[name] = OAS3ExampleObj{Value: value}
+	}
+
+	return examples
+}
+
+func oas3Param(p ParamObj) OAS3ParamObj {
+	s := oas3Schema(paramSchema(p))
+	s.Description = ""
+
+	res := OAS3ParamObj{
+		Name:        p.Name,
+		In:          p.In,
+		Description: p.Description,
+		Required:    p.Required,
+		Schema:      &s,
+	}
+
+	if p.Type == "array" {
+		res.Style, res.Explode = oas3Style(p.In, p.CollectionFormat)
+
+		// Format without OpenAPI 3.0 equivalent is kept as vendor extension.
+		if res.Style == "" && p.CollectionFormat != "" {
+			res.AddExtendedField(xCollectionFormat, p.CollectionFormat)
+		}
+	}
+
+	if p.style == styleDeepObject {
+		explode := true
+		res.Style, res.Explode = p.style, &explode
+	}
+
+	for k, v := range p.data {
+		res.AddExtendedField(k, v)
+	}
+
+	return res
+}
+
+// paramSchema converts non-body parameter to schema.
+func paramSchema(p ParamObj) SchemaObj {
+	if p.Schema != nil {
+		return *p.Schema
+	}
+
+	s := SchemaObj{CommonFields: p.CommonFields}
+	s.Items = paramItemSchema(p.Items)
+
+	return s
+}
+
+func paramItemSchema(items *ParamItemObj) *SchemaObj {
+	if items == nil {
+		return nil
+	}
+
+	s := SchemaObj{CommonFields: items.CommonFields}
+	s.Items = paramItemSchema(items.Items)
+
+	return &s
+}
+
+// oas3Style maps Swagger 2.0 collectionFormat to OpenAPI 3.0 style and explode.
+//
+// Empty style is returned for formats that have no equivalent in location, e.g. "tsv" or "pipes" in header.
+func oas3Style(in, collectionFormat string) (string, *bool) {
+	explode := false
+
+	// Path and header only support comma-separated "simple" style, multiple header lines are joined with comma.
+	if in == "path" || in == "header" {
+		switch collectionFormat {
+		case "", "csv", "multi":
+			return "simple", &explode
+		}
+
+		return "", nil
+	}
+
+	switch collectionFormat {
+	case "multi":
+		explode = true
+
+		return "form", &explode
+	case "", "csv":
+		return "form", &explode
+	case "ssv":
+		if in != "cookie" {
+			return "spaceDelimited", &explode
+		}
+	case "pipes":
+		if in != "cookie" {
+			return "pipeDelimited", &explode
+		}
+	}
+
+	return "", nil
+}
+
+// oas3Schema returns a copy of schema with references and Swagger 2.0 specific keywords adapted to OpenAPI 3.0.
+func oas3Schema(s SchemaObj) SchemaObj {
+	if s.Ref != "" {
+		s.Ref = refComponentsPrefix + strings.TrimPrefix(s.Ref, refDefinitionPrefix)
+	}
+
+	if s.Type == "file" {
+		s.Type = "string"
+		s.Format = "binary"
+	}
+
+	if len(s.data) > 0 || s.Nullable || len(s.OneOf) > 0 || len(s.AnyOf) > 0 || s.Discriminator != "" {
+		data := make(map[string]interface{}, len(s.data)+1)
+		for k, v := range s.data {
+			data[k] = v
+		}
+
+		s.data = data
+	}
+
+	if s.Nullable {
+		s.Nullable = false
+		s.data["nullable"] = true
+	}
+
+	// Swagger 2.0 extensions are replaced with OpenAPI 3.0 keywords.
+	if len(s.OneOf) > 0 {
+		s.data[compositionOneOf] = oas3Schemas(s.OneOf)
+		s.OneOf = nil
+	}
+
+	if len(s.AnyOf) > 0 {
+		s.data[compositionAnyOf] = oas3Schemas(s.AnyOf)
+		s.AnyOf = nil
+	}
+
+	if len(s.AllOf) > 0 {
+		s.AllOf = oas3Schemas(s.AllOf)
+	}
+
+	if s.Discriminator != "" {
+		discriminator := map[string]interface{}{"propertyName": s.Discriminator}
+
+		if len(s.DiscriminatorMapping) > 0 {
+			mapping := make(map[string]string, len(s.DiscriminatorMapping))
+
+			for value, ref := range s.DiscriminatorMapping {
+				mapping[value] = refComponentsPrefix + strings.TrimPrefix(ref, refDefinitionPrefix)
+			}
+
+			discriminator["mapping"] = mapping
+		}
+
+		s.data["discriminator"] = discriminator
+		s.Discriminator = ""
+		s.DiscriminatorMapping = nil
+	}
+
+	if s.Items != nil {
+		items := oas3Schema(*s.Items)
+		s.Items = &items
+	}
+
+	if s.AdditionalProperties != nil {
+		ap := oas3Schema(*s.AdditionalProperties)
+		s.AdditionalProperties = &ap
+	}
+
+	if s.Properties != nil {
+		props := make(map[string]SchemaObj, len(s.Properties))
+
+		for name, prop := range s.Properties {
+			props[name] = oas3Schema(prop)
+		}
+
+		s.Properties = props
+	}
+
+	return s
+}
+
+func oas3Schemas(schemas []SchemaObj) []SchemaObj {
+	res := make([]SchemaObj, 0, len(schemas))
+
+	for _, s := range schemas {
+		res = append(res, oas3Schema(s))
+	}
+
+	return res
+}
@@ -0,0 +1,319 @@
+package swgen_test
+
+import (
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/swgen"
+)
+
+type oas3Error struct {
+	Message string `json:"message"`
+}
+
+type oas3UUID [16]byte
+
+func TestGenerator_GenDocumentOAS3(t *testing.T) {
+	type (
+		item struct {
+			ID   oas3UUID `json:"id"`
+			Name string   `json:"name" minLength:"1"`
+		}
+
+		listReq struct {
+			Tags  []string `query:"tags" collectionFormat:"csv"`
+			Token string   `header:"X-Token" required:"true"`
+		}
+
+		uploadReq struct {
+			ID   int                   `path:"id"`
+			Note string                `formData:"note" required:"true"`
+			File *multipart.FileHeader `file:"file"`
+		}
+	)
+
+	g := swgen.NewGenerator()
+	g.SetHost("api.example.com").SetBasePath("/v1")
+	g.SetInfo("Title", "Description", "", "1.0")
+	g.AddExtendedField("x-audience", "internal")
+	g.AddDefaultResponse(http.StatusInternalServerError, oas3Error{})
+
+	uuidDef := swgen.SwaggerData{}
+	uuidDef.Type = "string"
+	uuidDef.Format = "uuid"
+	g.AddTypeMap(oas3UUID{}, uuidDef)
+
+	g.AddSecurityDefinition("oauth", swgen.SecurityDef{
+		Type:             swgen.SecurityOAuth2,
+		Flow:             swgen.Oauth2AccessCode,
+		AuthorizationURL: "https://example.com/authorize",
+		TokenURL:         "https://example.com/token",
+		Scopes:           map[string]string{"read": "Read access"},
+	})
+	g.AddSecurityDefinition("bearer", swgen.SecurityDef{Type: swgen.SecurityBearerToken, BearerFormat: "JWT"})
+
+	list := swgen.PathItemInfo{
+		Method:         http.MethodGet,
+		Path:           "/items",
+		Title:          "List items",
+		Request:        new(listReq),
+		Response:       new([]item),
+		Produces:       []string{"application/json", "application/xml"},
+		SecurityOAuth2: map[string][]string{"oauth": {"read"}},
+	}
+	list.AddExtendedField("x-internal", true)
+	g.SetPathItem(list)
+
+	g.SetPathItem(swgen.PathItemInfo{
+		Method:   http.MethodPost,
+		Path:     "/items/{id}/upload",
+		Request:  new(uploadReq),
+		Security: []string{"bearer"},
+	})
+
+	g.SetPathItem(swgen.PathItemInfo{
+		Method:   http.MethodPut,
+		Path:     "/items/{id}",
+		Request:  new(item),
+		Response: new(item),
+		Consumes: []string{"application/json"},
+	})
+
+	b, err := g.GenDocumentOAS3()
+	require.NoError(t, err)
+	assertjson.Equal(t, []byte(`{
+	    "openapi": "3.0.3",
+	    "info": {
+	      "title": "Title",
+	      "description": "Description",
+	      "termsOfService": "",
+	      "contact": {
+	        "name": ""
+	      },
+	      "license": {
+	        "name": ""
+	      },
+	      "version": "1.0"
+	    },
+	    "servers": [
+	      {
+	        "url": "http://api.example.com/v1"
+	      },
+	      {
+	        "url": "https://api.example.com/v1"
+	      }
+	    ],
+	    "paths": {
+	      "/items": {
+	        "get": {
+	          "summary": "List items",
+	          "parameters": [
+	            {
+	              "name": "tags",
+	              "in": "query",
+	              "style": "form",
+	              "explode": false,
+	              "schema": {
+	                "type": "array",
+	                "items": {
+	                  "type": "string"
+	                }
+	              }
+	            },
+	            {
+	              "name": "X-Token",
+	              "in": "header",
+	              "required": true,
+	              "schema": {
+	                "type": "string"
+	              }
+	            }
+	          ],
+	          "responses": {
+	            "200": {
+	              "description": "OK",
+	              "content": {
+	                "application/json": {
+	                  "schema": {
+	                    "type": "array",
+	                    "items": {
+	                      "$ref": "#/components/schemas/item"
+	                    }
+	                  }
+	                },
+	                "application/xml": {
+	                  "schema": {
+	                    "type": "array",
+	                    "items": {
+	                      "$ref": "#/components/schemas/item"
+	                    }
+	                  }
+	                }
+	              }
+	            },
+	            "500": {
+	              "description": "Internal Server Error",
+	              "content": {
+	                "application/json": {
+	                  "schema": {
+	                    "$ref": "#/components/schemas/oas3Error"
+	                  }
+	                },
+	                "application/xml": {
+	                  "schema": {
+	                    "$ref": "#/components/schemas/oas3Error"
+	                  }
+	                }
+	              }
+	            }
+	          },
+	          "security": [
+	            {
+	              "oauth": [
+	                "read"
+	              ]
+	            }
+	          ],
+	          "x-internal": true
+	        }
+	      },
+	      "/items/{id}": {
+	        "put": {
+	          "requestBody": {
+	            "content": {
+	              "application/json": {
+	                "schema": {
+	                  "$ref": "#/components/schemas/item"
+	                }
+	              }
+	            },
+	            "required": true
+	          },
+	          "responses": {
+	            "200": {
+	              "description": "OK",
+	              "content": {
+	                "application/json": {
+	                  "schema": {
+	                    "$ref": "#/components/schemas/item"
+	                  }
+	                }
+	              }
+	            },
+	            "500": {
+	              "description": "Internal Server Error",
+	              "content": {
+	                "application/json": {
+	                  "schema": {
+	                    "$ref": "#/components/schemas/oas3Error"
+	                  }
+	                }
+	              }
+	            }
+	          }
+	        }
+	      },
+	      "/items/{id}/upload": {
+	        "post": {
+	          "parameters": [
+	            {
+	              "name": "id",
+	              "in": "path",
+	              "required": true,
+	              "schema": {
+	                "type": "integer",
+	                "format": "int32"
+	              }
+	            }
+	          ],
+	          "requestBody": {
+	            "content": {
+	              "multipart/form-data": {
+	                "schema": {
+	                  "type": "object",
+	                  "required": [
+	                    "note"
+	                  ],
+	                  "properties": {
+	                    "file": {
+	                      "type": "string",
+	                      "format": "binary"
+	                    },
+	                    "note": {
+	                      "type": "string"
+	                    }
+	                  }
+	                }
+	              }
+	            }
+	          },
+	          "responses": {
+	            "500": {
+	              "description": "Internal Server Error",
+	              "content": {
+	                "application/json": {
+	                  "schema": {
+	                    "$ref": "#/components/schemas/oas3Error"
+	                  }
+	                }
+	              }
+	            }
+	          },
+	          "security": [
+	            {
+	              "bearer": []
+	            }
+	          ]
+	        }
+	      }
+	    },
+	    "components": {
+	      "schemas": {
+	        "item": {
+	          "type": "object",
+	          "properties": {
+	            "id": {
+	              "type": "string",
+	              "format": "uuid"
+	            },
+	            "name": {
+	              "type": "string",
+	              "minLength": 1
+	            }
+	          }
+	        },
+	        "oas3Error": {
+	          "type": "object",
+	          "properties": {
+	            "message": {
+	              "type": "string"
+	            }
+	          }
+	        }
+	      },
+	      "securitySchemes": {
+	        "bearer": {
+	          "type": "http",
+	          "scheme": "bearer",
+	          "bearerFormat": "JWT"
+	        },
+	        "oauth": {
+	          "type": "oauth2",
+	          "flows": {
+	            "authorizationCode": {
+	              "authorizationUrl": "https://example.com/authorize",
+	              "tokenUrl": "https://example.com/token",
+	              "scopes": {
+	                "read": "Read access"
+	              }
+	            }
+	          }
+	        }
+	      }
+	    },
+	    "x-audience": "internal"
+	  }`), b, string(b))
+}
@@ -4,6 +4,7 @@ import (
 	"encoding"
 	"encoding/json"
 	"fmt"
+	"hash/crc32"
 	"net/http"
 	"path"
 	"reflect"
@@ -106,6 +107,10 @@ func (g *Generator) addDefinition(t reflect.Type, typeDef *SchemaObj) {
 		return
 	}
 
+	if typeDef.Description == "" {
+		typeDef.Description = g.comment(t, "")
+	}
+
 	typeDef.TypeName = g.makeNameForType(t, typeDef.TypeName)
 	g.definitions[refl.GoType(t)] = *typeDef
 }
@@ -148,11 +153,40 @@ func (g *Generator) deleteDefinition(t reflect.Type) {
 func (g *Generator) ResetDefinitions() {
 	g.definitions = make(defMap)
 	g.defQueue = make(map[refl.TypeString]reflect.Type)
+	g.ResetDocumentCache()
 }
 
 // ParseDefinition create a DefObj from input object, it should be a non-nil pointer to anything
 // it reuse schema/json tag for property name.
+//
+// It panics if definition can not be reflected, use TryParseDefinition to receive an error instead.
 func (g *Generator) ParseDefinition(i interface{}) SchemaObj {
+	typeDef, err := g.TryParseDefinition(i)
+	if err != nil {
+		panic(err)
+	}
+
+	return typeDef
+}
+
+// TryParseDefinition creates a schema object from input object.
+//
+// It returns ReflectError with all problems found in the type and its nested types.
+func (g *Generator) TryParseDefinition(i interface{}) (SchemaObj, error) {
+	g.reflectErrors = nil
+
+	defer g.ResetDocumentCache()
+
+	typeDef := g.parseDefinition(i)
+
+	if errs := g.takeReflectErrors(); len(errs) > 0 {
+		return typeDef, ReflectError{Errors: errs}
+	}
+
+	return typeDef, nil
+}
+
+func (g *Generator) parseDefinition(i interface{}) SchemaObj {
 	var (
 		typeName string
 		typeDef  SchemaObj
@@ -170,7 +204,7 @@ func (g *Generator) ParseDefinition(i interface{}) SchemaObj {
 		v = reflect.ValueOf(mappedTo)
 	}
 
-	if definition, ok := swaggerData(i); ok {
+	if definition, ok := g.swaggerData(i); ok {
 		typeDef = definition.Schema()
 		if typeDef.TypeName == "" {
 			typeName = t.Name()
@@ -200,6 +234,12 @@ func (g *Generator) ParseDefinition(i interface{}) SchemaObj {
 	name := refl.GoType(t) // todo remove
 	_ = name
 
+	if c, ok := g.getComposition(t); ok {
+		defer g.parseDefInQueue()
+
+		return g.compositionSchema(t, c, typeName)
+	}
+
 	// Shortcut on embedded map or slice.
 	if et := refl.FindEmbeddedSliceOrMap(i); et != nil {
 		t = et
@@ -321,6 +361,7 @@ func (g *Generator) parseDefinitionProperties(v reflect.Value, parent *SchemaObj
 		}
 
 		propName := strings.Split(tag, ",")[0]
+		restoreScope := g.enterScope(string(refl.GoType(t)), field.Name)
 
 		var (
 			obj      SchemaObj
@@ -328,7 +369,7 @@ func (g *Generator) parseDefinitionProperties(v reflect.Value, parent *SchemaObj
 		)
 
 		if mapped, found := g.getMappedType(field.Type); found {
-			if def, ok := swaggerData(mapped); ok {
+			if def, ok := g.swaggerData(mapped); ok {
 				obj = def.Schema()
 				objReady = true
 			} else {
@@ -337,7 +378,9 @@ func (g *Generator) parseDefinitionProperties(v reflect.Value, parent *SchemaObj
 		}
 
 		if !objReady {
-			if field.Type.Kind() == reflect.Interface && v.Field(i).Elem().IsValid() {
+			if _, composed := g.getComposition(field.Type); composed {
+				obj = g.genSchemaForType(field.Type, parent.Ref+field.Name)
+			} else if field.Type.Kind() == reflect.Interface && v.Field(i).Elem().IsValid() {
 				obj = g.genSchemaForType(v.Field(i).Elem().Type(), parent.Ref+field.Name)
 			} else {
 				typeName := refl.GoType(field.Type)
@@ -354,6 +397,8 @@ func (g *Generator) parseDefinitionProperties(v reflect.Value, parent *SchemaObj
 
 		if description := field.Tag.Get("description"); description != "" {
 			obj.Description = description
+		} else if comment := g.comment(t, field.Name); comment != "" {
+			obj.Description = comment
 		}
 
 		if defaultTag := field.Tag.Get("default"); defaultTag != "" {
@@ -371,8 +416,12 @@ func (g *Generator) parseDefinitionProperties(v reflect.Value, parent *SchemaObj
 			parent.GoPropertyTypes[propName] = string(refl.GoType(oft))
 		}
 
-		readSharedTags(field.Tag, &obj.CommonFields)
-		readBoolTag(field.Tag, "required", &obj.isRequired)
+		for _, err := range readSharedTags(field.Tag, &obj.CommonFields) {
+			g.addReflectError(err)
+		}
+
+		g.addReflectError(readBoolTag(field.Tag, "required", &obj.isRequired))
+		restoreScope()
 
 		properties[propName] = obj
 	}
@@ -411,16 +460,16 @@ func (g *Generator) parseDefInQueue() {
 		return
 	}
 
-	for name, t := range g.defQueue {
-		_ = name
+	for _, name := range sortedKeys(g.defQueue) {
+		t := g.defQueue[refl.TypeString(name)]
 		z := reflect.Zero(t).Interface()
-		g.ParseDefinition(z)
+		g.parseDefinition(z)
 	}
 }
 
 // reflectTypeReliableName returns real name of given reflect.Type.
 func (g *Generator) reflectTypeReliableName(t reflect.Type, fallbackRef string) string {
-	if def, ok := swaggerData(reflect.Zero(t).Interface()); ok {
+	if def, ok := g.swaggerData(reflect.Zero(t).Interface()); ok {
 		typeDef := def
 		if typeDef.TypeName != "" {
 			return typeDef.TypeName
@@ -437,7 +486,7 @@ func (g *Generator) reflectTypeReliableName(t reflect.Type, fallbackRef string)
 		return strings.TrimPrefix(fallbackRef, "#/definitions/")
 	}
 
-	return fmt.Sprintf("anon_%08x", reflect.Indirect(reflect.ValueOf(t)).FieldByName("hash").Uint())
+	return fmt.Sprintf("anon_%08x", crc32.ChecksumIEEE([]byte(t.String())))
 }
 
 func (g *Generator) genSchemaForType(t reflect.Type, fallbackRef string) SchemaObj {
@@ -447,6 +496,11 @@ func (g *Generator) genSchemaForType(t reflect.Type, fallbackRef string) SchemaO
 	}
 
 	t = refl.DeepIndirect(t)
+
+	if c, ok := g.getComposition(t); ok {
+		return g.compositionSchema(t, c, fallbackRef)
+	}
+
 	smObj := SchemaObj{TypeName: t.Name()}
 	typeName := refl.GoType(t)
 
@@ -501,13 +555,13 @@ func (g *Generator) genSchemaForType(t reflect.Type, fallbackRef string) SchemaO
 			smObj = SchemaObj{}
 			smObj.Type = "file"
 		} else if t.NumMethod() > 0 {
-			panic("Non-empty interface is not supported: " + typeName)
+			g.addReflectError(FieldError{Value: string(typeName), Err: errNonEmptyInterface})
 		}
 	default:
-		panic(fmt.Sprintf("type %s is not supported: %s", t.Kind(), typeName))
+		g.addReflectError(FieldError{Value: string(typeName), Err: fmt.Errorf("%w: %s", errUnsupportedKind, t.Kind())})
 	}
 
-	if sd, ok := swaggerData(reflect.New(t).Interface()); ok {
+	if sd, ok := g.swaggerData(reflect.New(t).Interface()); ok {
 		name := g.reflectTypeReliableName(t, fallbackRef)
 
 		smObj = sd.Schema()
@@ -526,9 +580,19 @@ func (g *Generator) genSchemaForType(t reflect.Type, fallbackRef string) SchemaO
 	return smObj
 }
 
-func swaggerData(v interface{}) (SwaggerData, bool) {
+// swaggerData loads custom definition exposed by value and records a problem if exposer fails.
+func (g *Generator) swaggerData(v interface{}) (SwaggerData, bool) {
+	sd, ok, err := loadSwaggerData(v)
+	if err != nil {
+		g.addReflectError(FieldError{Value: string(refl.GoType(reflect.TypeOf(v))), Err: err})
+	}
+
+	return sd, ok
+}
+
+func loadSwaggerData(v interface{}) (SwaggerData, bool, error) {
 	if sd, ok := v.(SchemaDefinition); ok {
-		return sd.SwaggerDef(), true
+		return sd.SwaggerDef(), true, nil
 	}
 
 	if ex, ok := v.(jsonschema.Exposer); ok {
@@ -536,24 +600,24 @@ func swaggerData(v interface{}) (SwaggerData, bool) {
 
 		s, err := ex.JSONSchema()
 		if err != nil {
-			panic(err)
+			return sd, false, err
 		}
 
 		j, err := json.Marshal(s)
 		if err != nil {
-			panic(err)
+			return sd, false, err
 		}
 
 		err = json.Unmarshal(j, &sd)
 		if err != nil {
-			panic(err)
+			return sd, false, err
 		}
 
 		if len(s.Examples) > 0 {
 			sd.Example = s.Examples[0]
 		}
 
-		return sd, true
+		return sd, true, nil
 	}
 
 	if ex, ok := v.(jsonschema.RawExposer); ok {
@@ -561,29 +625,29 @@ func swaggerData(v interface{}) (SwaggerData, bool) {
 
 		j, err := ex.JSONSchemaBytes()
 		if err != nil {
-			panic(err)
+			return sd, false, err
 		}
 
 		s := jsonschema.Schema{}
 
 		err = json.Unmarshal(j, &s)
 		if err != nil {
-			panic(err)
+			return sd, false, err
 		}
 
 		err = json.Unmarshal(j, &sd)
 		if err != nil {
-			panic(err)
+			return sd, false, err
 		}
 
 		if len(s.Examples) > 0 {
 			sd.Example = s.Examples[0]
 		}
 
-		return sd, true
+		return sd, true, nil
 	}
 
-	return SwaggerData{}, false
+	return SwaggerData{}, false, nil
 }
 
 //
@@ -592,7 +656,23 @@ func swaggerData(v interface{}) (SwaggerData, bool) {
 //
 
 // ParseParameters parse input struct to swagger parameter object.
+//
+// It panics if parameters can not be reflected.
 func (g *Generator) ParseParameters(i interface{}) (string, []ParamObj) {
+	g.reflectErrors = nil
+
+	defer g.ResetDocumentCache()
+
+	name, params := g.parseParameters(i)
+
+	if errs := g.takeReflectErrors(); len(errs) > 0 {
+		panic(ReflectError{Errors: errs})
+	}
+
+	return name, params
+}
+
+func (g *Generator) parseParameters(i interface{}) (string, []ParamObj) {
 	v := reflect.ValueOf(i)
 
 	if v.Kind() == reflect.Ptr {
@@ -607,7 +687,7 @@ func (g *Generator) ParseParameters(i interface{}) (string, []ParamObj) {
 	t := v.Type()
 
 	if mappedTo, ok := g.getMappedType(t); ok {
-		return g.ParseParameters(mappedTo)
+		return g.parseParameters(mappedTo)
 	}
 
 	requestTypeName := refl.GoType(v.Type())
@@ -615,7 +695,7 @@ func (g *Generator) ParseParameters(i interface{}) (string, []ParamObj) {
 	numField := t.NumField()
 	params := make([]ParamObj, 0, numField)
 
-	filesFound := map[string]bool{}
+	filesFound := map[string]int{}
 
 	for i := 0; i < numField; i++ {
 		field := t.Field(i)
@@ -626,7 +706,7 @@ func (g *Generator) ParseParameters(i interface{}) (string, []ParamObj) {
 
 		if field.Anonymous && field.Tag.Get("in") != "body" {
 			anonValue := reflect.New(field.Type).Interface()
-			_, anonParams := g.ParseParameters(anonValue)
+			_, anonParams := g.parseParameters(anonValue)
 			params = append(params, anonParams...)
 
 			continue
@@ -666,17 +746,22 @@ func (g *Generator) ParseParameters(i interface{}) (string, []ParamObj) {
 
 		paramName := strings.Split(nameTag, ",")[0]
 		param := ParamObj{}
+		restoreScope := g.enterScope(string(requestTypeName), field.Name)
 
-		if def, ok := swaggerData(reflect.Zero(field.Type).Interface()); ok {
+		if def, ok := g.swaggerData(reflect.Zero(field.Type).Interface()); ok {
 			param = def.Param()
 		} else {
 			var schemaObj SchemaObj
 			fieldTypeName := refl.GoType(field.Type)
 			if fieldTypeName == "*mime/multipart.FileHeader" {
 				schemaObj.Type = "file"
+			} else if fieldTypeName == "[]*mime/multipart.FileHeader" {
+				schemaObj.Type = "array"
+				schemaObj.Items = &SchemaObj{}
+				schemaObj.Items.Type = "file"
 			} else {
 				if mappedTo, ok := g.getMappedType(field.Type); ok {
-					if def, ok := swaggerData(mappedTo); ok {
+					if def, ok := g.swaggerData(mappedTo); ok {
 						schemaObj = def.Schema()
 						if schemaObj.TypeName != "" {
 							name = schemaObj.TypeName
@@ -689,8 +774,33 @@ func (g *Generator) ParseParameters(i interface{}) (string, []ParamObj) {
 				}
 			}
 
+			if in == "formData" && schemaObj.Type == "" && schemaObj.Ref != "" {
+				// Structure is sent as a part of multipart form with its own content type.
+				part := schemaObj
+				param.partSchema = &part
+				schemaObj = schemaFromCommonName(commonNameString)
+			}
+
+			if field.Tag.Get("style") == styleDeepObject {
+				if err := deepObjectParam(in, &param, &schemaObj); err != nil {
+					g.addReflectError(err)
+					restoreScope()
+
+					continue
+				}
+
+				// Definitions of object are needed to validate and flatten parameter.
+				g.parseDefInQueue()
+
+				// Arrays of flattened Swagger 2.0 parameters are serialized with collectionFormat of parameter.
+				param.CollectionFormat = g.defaultCollectionFormat(in)
+			}
+
 			if schemaObj.Type == "" {
-				panic("unsupported field " + field.Name + " of type " + string(fieldTypeName) + " in request of type " + string(requestTypeName))
+				g.addReflectError(FieldError{Value: string(fieldTypeName), Err: errUnsupportedParamType})
+				restoreScope()
+
+				continue
 			}
 
 			param.CommonFields = schemaObj.CommonFields
@@ -699,7 +809,7 @@ func (g *Generator) ParseParameters(i interface{}) (string, []ParamObj) {
 				if schemaObj.Items.Ref != "" {
 					fieldType := refl.DeepIndirect(field.Type)
 					if fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array {
-						g.ParseDefinition(reflect.Zero(field.Type.Elem()).Interface())
+						g.parseDefinition(reflect.Zero(field.Type.Elem()).Interface())
 
 						if def, ok := g.getDefinition(field.Type.Elem()); ok {
 							schemaObj.Items = &def
@@ -707,15 +817,16 @@ func (g *Generator) ParseParameters(i interface{}) (string, []ParamObj) {
 					}
 				}
 
-				if schemaObj.Items.Ref != "" || schemaObj.Items.Type == "array" || schemaObj.Items.Type == "object" {
-					panic("unsupported array of struct or nested array in parameter: " + fieldTypeName)
+				items, ok := paramItems(schemaObj.Items)
+				if !ok {
+					g.addReflectError(FieldError{Value: string(fieldTypeName), Err: errUnsupportedParamItems})
+					restoreScope()
+
+					continue
 				}
 
-				param.Items = &ParamItemObj{}
-				param.Items.CommonFields = schemaObj.Items.CommonFields
-				param.Items.Title = ""
-				param.Items.Description = ""
-				param.CollectionFormat = "multi" // default for now
+				param.Items = items
+				param.CollectionFormat = g.defaultCollectionFormat(in)
 			}
 		}
 
@@ -727,23 +838,50 @@ func (g *Generator) ParseParameters(i interface{}) (string, []ParamObj) {
 		param.Name = paramName
 
 		param.Enum.LoadFromField(field)
-		readSharedTags(field.Tag, &param.CommonFields)
-		readStringTag(field.Tag, "collectionFormat", &param.CollectionFormat)
+		for _, err := range readSharedTags(field.Tag, &param.CommonFields) {
+			g.addReflectError(err)
+		}
+
+		readCollectionFormat(field.Tag, &param)
+
+		// Files are sent as separate parts, other collection formats do not apply.
+		if isFileParam(param) && param.Type == "array" {
+			param.CollectionFormat = "multi"
+		}
+
+		if in == "formData" {
+			for _, err := range readPartTags(field.Tag, &param) {
+				g.addReflectError(err)
+			}
+		}
+
+		if param.Description == "" {
+			param.Description = g.comment(t, field.Name)
+		}
 
 		if in == "path" { // always true for path
 			param.Required = true
 		} else if in != "body" { // always unset for body
 			// not required by default for others
-			readBoolTag(field.Tag, "required", &param.Required)
+			g.addReflectError(readBoolTag(field.Tag, "required", &param.Required))
 		}
 
+		restoreScope()
+
 		param.In = in
-		if param.Type == "file" {
-			if filesFound[param.Name] {
+		if isFileParam(param) {
+			// multipart.File and *multipart.FileHeader fields can describe the same upload.
+			if j, found := filesFound[param.Name]; found {
+				if params[j].Type != param.Type {
+					g.addReflectError(FieldError{
+						GoType: string(requestTypeName), Field: field.Name, Value: param.Name, Err: errConflictingFileParam,
+					})
+				}
+
 				continue
-			} else {
-				filesFound[param.Name] = true
 			}
+
+			filesFound[param.Name] = len(params)
 		}
 
 		params = append(params, param)
@@ -752,27 +890,41 @@ func (g *Generator) ParseParameters(i interface{}) (string, []ParamObj) {
 	return name, params
 }
 
-func readSharedTags(tag reflect.StructTag, param *CommonFields) {
+func readSharedTags(tag reflect.StructTag, param *CommonFields) []error {
 	readStringTag(tag, "type", &param.Type)
 	readStringTag(tag, "title", &param.Title)
 	readStringTag(tag, "description", &param.Description)
 	readStringTag(tag, "format", &param.Format)
 	readStringTag(tag, "pattern", &param.Pattern)
 
-	readIntPtrTag(tag, "maxLength", &param.MaxLength)
-	readIntPtrTag(tag, "minLength", &param.MinLength)
-	readIntPtrTag(tag, "maxItems", &param.MaxItems)
-	readIntPtrTag(tag, "minItems", &param.MinItems)
-	readIntPtrTag(tag, "maxProperties", &param.MaxProperties)
-	readIntPtrTag(tag, "minProperties", &param.MinProperties)
+	return nonNilErrors(
+		readIntPtrTag(tag, "maxLength", &param.MaxLength),
+		readIntPtrTag(tag, "minLength", &param.MinLength),
+		readIntPtrTag(tag, "maxItems", &param.MaxItems),
+		readIntPtrTag(tag, "minItems", &param.MinItems),
+		readIntPtrTag(tag, "maxProperties", &param.MaxProperties),
+		readIntPtrTag(tag, "minProperties", &param.MinProperties),
+
+		readFloatTag(tag, "multipleOf", &param.MultipleOf),
+		readFloatPtrTag(tag, "maximum", &param.Maximum),
+		readFloatPtrTag(tag, "minimum", &param.Minimum),
+
+		readBoolTag(tag, "exclusiveMaximum", &param.ExclusiveMaximum),
+		readBoolTag(tag, "exclusiveMinimum", &param.ExclusiveMinimum),
+		readBoolTag(tag, "uniqueItems", &param.UniqueItems),
+	)
+}
 
-	readFloatTag(tag, "multipleOf", &param.MultipleOf)
-	readFloatPtrTag(tag, "maximum", &param.Maximum)
-	readFloatPtrTag(tag, "minimum", &param.Minimum)
+func nonNilErrors(errs ...error) []error {
+	var res []error
 
-	readBoolTag(tag, "exclusiveMaximum", &param.ExclusiveMaximum)
-	readBoolTag(tag, "exclusiveMinimum", &param.ExclusiveMinimum)
-	readBoolTag(tag, "uniqueItems", &param.UniqueItems)
+	for _, err := range errs {
+		if err != nil {
+			res = append(res, err)
+		}
+	}
+
+	return res
 }
 
 func readStringTag(tag reflect.StructTag, name string, holder *string) {
@@ -788,70 +940,87 @@ func readStringTag(tag reflect.StructTag, name string, holder *string) {
 	}
 }
 
-func readBoolTag(tag reflect.StructTag, name string, holder *bool) {
+func readBoolTag(tag reflect.StructTag, name string, holder *bool) error {
 	value, ok := tag.Lookup(name)
 	if ok {
 		v, err := strconv.ParseBool(value)
 		if err != nil {
-			panic("failed to parse bool value " + value + " in tag " + name + ": " + err.Error())
+			return FieldError{Tag: name, Value: value, Err: err}
 		}
 
 		*holder = v
 	}
+
+	return nil
 }
 
-func readIntPtrTag(tag reflect.StructTag, name string, holder **int64) {
+func readIntPtrTag(tag reflect.StructTag, name string, holder **int64) error {
 	value, ok := tag.Lookup(name)
 	if ok {
 		v, err := strconv.ParseInt(value, 10, 64)
 		if err != nil {
-			panic("failed to parse int value " + value + " in tag " + name + ": " + err.Error())
+			return FieldError{Tag: name, Value: value, Err: err}
 		}
 
 		*holder = new(int64)
 		**holder = v
 	}
+
+	return nil
 }
 
-func readFloatTag(tag reflect.StructTag, name string, holder *float64) {
+func readFloatTag(tag reflect.StructTag, name string, holder *float64) error {
 	value, ok := tag.Lookup(name)
 	if ok {
 		v, err := strconv.ParseFloat(value, 64)
 		if err != nil {
-			panic("failed to parse float value " + value + " in tag " + name + ": " + err.Error())
+			return FieldError{Tag: name, Value: value, Err: err}
 		}
 
 		*holder = v
 	}
+
+	return nil
 }
 
-func readFloatPtrTag(tag reflect.StructTag, name string, holder **float64) {
+func readFloatPtrTag(tag reflect.StructTag, name string, holder **float64) error {
 	value, ok := tag.Lookup(name)
 	if ok {
 		v, err := strconv.ParseFloat(value, 64)
 		if err != nil {
-			panic("failed to parse float value " + value + " in tag " + name + ": " + err.Error())
+			return FieldError{Tag: name, Value: value, Err: err}
 		}
 
 		*holder = new(float64)
 		**holder = v
 	}
+
+	return nil
 }
 
 // ResetPaths remove all current paths.
 func (g *Generator) ResetPaths() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
 	g.paths = make(map[string]PathItem)
+	g.pathPatterns = make(map[string]string)
+	g.ResetDocumentCache()
 }
 
 var regexFindPathParameter = regexp.MustCompile(`\{([^}:]+)(:[^\/]+)?(?:\})`)
 
-func setOpenAPIPathItem(info PathItemInfo, g *openapi3.Reflector) error {
+func setOpenAPIPathItem(info PathItemInfo, g *openapi3.Reflector, collectionFormat func(in string) string) error {
 	op := openapi3.Operation{}
 	if info.Request != nil {
 		err := g.SetRequest(&op, info.Request, info.Method)
 		if err != nil {
 			return err
 		}
+
+		setOpenAPIEncoding(&op, info.Request, g)
+		setOpenAPIDeepObjects(&op)
+		setOpenAPICollectionFormats(&op, info.Request, collectionFormat)
 	}
 
 	if info.Response != nil {
@@ -866,6 +1035,10 @@ func setOpenAPIPathItem(info PathItemInfo, g *openapi3.Reflector) error {
 		}
 	} else {
 		httpStatus := http.StatusNoContent
+		if len(info.ResponseContent) > 0 {
+			httpStatus = http.StatusOK
+		}
+
 		if info.SuccessfulResponseCode != 0 {
 			httpStatus = info.SuccessfulResponseCode
 		}
@@ -879,13 +1052,17 @@ func setOpenAPIPathItem(info PathItemInfo, g *openapi3.Reflector) error {
 		})
 	}
 
-	for httpStatus, response := range info.Responses() {
-		err := g.SetJSONResponse(&op, response, httpStatus)
+	for _, httpStatus := range sortedIntKeys(info.Responses()) {
+		err := g.SetJSONResponse(&op, info.Responses()[httpStatus], httpStatus)
 		if err != nil {
 			return err
 		}
 	}
 
+	if err := setOpenAPIContent(info, g, &op); err != nil {
+		return err
+	}
+
 	if info.Deprecated {
 		op.WithDeprecated(true)
 	}
@@ -910,16 +1087,91 @@ func setOpenAPIPathItem(info PathItemInfo, g *openapi3.Reflector) error {
 		op.WithDescription(info.Description)
 	}
 
-	return g.Spec.AddOperation(info.Method, info.Path, op)
+	return g.SpecEns().AddOperation(info.Method, info.Path, op)
 }
 
 // SetPathItem register path item with some information and input, output.
+//
+// It panics if operation can not be reflected, use TrySetPathItem to receive an error instead.
 func (g *Generator) SetPathItem(info PathItemInfo) *OperationObj {
-	if g.oas3Proxy != nil {
-		err := setOpenAPIPathItem(info, g.oas3Proxy)
+	op, err := g.TrySetPathItem(info)
+	if err != nil {
+		panic(err)
+	}
+
+	return op
+}
+
+// reflectSnapshot keeps definitions to roll back changes of failed operation reflection.
+type reflectSnapshot struct {
+	definitions defMap
+	defQueue    map[refl.TypeString]reflect.Type
+	schemas     map[string]openapi3.SchemaOrRef // Component schemas of OpenAPI 3.0 proxy.
+}
+
+func (g *Generator) snapshot() reflectSnapshot {
+	s := reflectSnapshot{
+		definitions: make(defMap, len(g.definitions)),
+		defQueue:    make(map[refl.TypeString]reflect.Type, len(g.defQueue)),
+	}
+
+	for k, v := range g.definitions {
+		s.definitions[k] = v
+	}
+
+	for k, v := range g.defQueue {
+		s.defQueue[k] = v
+	}
+
+	if g.oas3Proxy != nil && g.oas3Proxy.Spec != nil && g.oas3Proxy.Spec.Components != nil &&
+		g.oas3Proxy.Spec.Components.Schemas != nil {
+		s.schemas = make(map[string]openapi3.SchemaOrRef)
+
+		for k, v := range g.oas3Proxy.Spec.Components.Schemas.MapOfSchemaOrRefValues {
+			s.schemas[k] = v
+		}
+	}
+
+	return s
+}
+
+func (g *Generator) restore(s reflectSnapshot) {
+	g.definitions = s.definitions
+	g.defQueue = s.defQueue
+
+	if g.oas3Proxy != nil && g.oas3Proxy.Spec != nil && g.oas3Proxy.Spec.Components != nil &&
+		g.oas3Proxy.Spec.Components.Schemas != nil {
+		g.oas3Proxy.Spec.Components.Schemas.MapOfSchemaOrRefValues = s.schemas
+	}
+}
+
+// TrySetPathItem registers path item with some information and input, output.
+//
+// It returns ReflectError with all problems found in request and responses, operation is not registered
+// and definitions of Swagger 2.0 document and OpenAPI 3.0 proxy are not changed in such case.
+func (g *Generator) TrySetPathItem(info PathItemInfo) (*OperationObj, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.reflectErrors = nil
+
+	defer g.ResetDocumentCache()
+
+	snapshot := g.snapshot()
+	proxyInfo := info
+
+	// OpenAPI 3.0 proxy operation is only added once Swagger 2.0 operation is reflected without errors.
+	setProxy := func() {
+		if g.oas3Proxy == nil {
+			return
+		}
+
+		err := setOpenAPIPathItem(proxyInfo, g.oas3Proxy, g.configuredCollectionFormat)
 		if err != nil {
-			panic(fmt.Errorf("failed to add OpenAPI 3 operation %s %s: %v", info.Method, info.Path, err))
+			g.addReflectError(fmt.Errorf("failed to add OpenAPI 3 operation: %w", err))
 		}
+
+		proxyDiscriminators(g.oas3Proxy)
 	}
 
 	var (
@@ -951,6 +1203,8 @@ func (g *Generator) SetPathItem(info PathItemInfo) *OperationObj {
 
 	response := info.Response
 
+	pattern := info.Path
+
 	pathParametersSubmatches := regexFindPathParameter.FindAllStringSubmatch(info.Path, -1)
 	if len(pathParametersSubmatches) > 0 {
 		for _, submatch := range pathParametersSubmatches {
@@ -963,7 +1217,15 @@ func (g *Generator) SetPathItem(info PathItemInfo) *OperationObj {
 	item, found = g.paths[info.Path]
 
 	if found && item.HasMethod(info.Method) {
-		return item.Map()[info.Method]
+		setProxy()
+
+		if errs := g.takeReflectErrors(); len(errs) > 0 {
+			g.restore(snapshot)
+
+			return nil, ReflectError{Method: info.Method, Path: info.Path, Errors: errs}
+		}
+
+		return item.Map()[info.Method], nil
 	}
 
 	if !found {
@@ -973,6 +1235,10 @@ func (g *Generator) SetPathItem(info PathItemInfo) *OperationObj {
 	operationObj := &OperationObj{}
 	operationObj.Summary = info.Title
 	operationObj.Description = info.Description
+
+	if operationObj.Description == "" && info.Request != nil {
+		operationObj.Description = g.comment(reflect.TypeOf(info.Request), "")
+	}
 	operationObj.Deprecated = info.Deprecated
 	operationObj.Produces = info.Produces
 	operationObj.Consumes = info.Consumes
@@ -991,8 +1257,8 @@ func (g *Generator) SetPathItem(info PathItemInfo) *OperationObj {
 	}
 
 	if len(info.SecurityOAuth2) > 0 {
-		for sec, scopes := range info.SecurityOAuth2 {
-			operationObj.Security = append(operationObj.Security, map[string][]string{sec: scopes})
+		for _, sec := range sortedKeys(info.SecurityOAuth2) {
+			operationObj.Security = append(operationObj.Security, map[string][]string{sec: info.SecurityOAuth2[sec]})
 		}
 	}
 
@@ -1001,26 +1267,29 @@ func (g *Generator) SetPathItem(info PathItemInfo) *OperationObj {
 			operationObj.AddExtendedField("x-request-go-type", refl.GoType(reflect.TypeOf(params)))
 		}
 
-		_, params := g.ParseParameters(params)
+		_, params := g.parseParameters(params)
 		operationObj.Parameters = params
+
+		g.checkCookieParams(info.Request, params)
 	}
 
 	if g.defaultResponses != nil {
-		for statusCode, r := range g.defaultResponses {
-			g.parseResponseObject(operationObj, statusCode, r)
+		for _, statusCode := range sortedIntKeys(g.defaultResponses) {
+			g.parseResponseObject(operationObj, statusCode, g.defaultResponses[statusCode])
 		}
 	}
 
 	if info.responses != nil {
-		for statusCode, r := range info.responses {
-			g.parseResponseObject(operationObj, statusCode, r)
+		for _, statusCode := range sortedIntKeys(info.responses) {
+			g.parseResponseObject(operationObj, statusCode, info.responses[statusCode])
 		}
 	}
 
-	if response != nil || operationObj.Responses == nil || info.SuccessfulResponseCode != 0 {
+	if response != nil || operationObj.Responses == nil || info.SuccessfulResponseCode != 0 ||
+		len(info.ResponseContent) > 0 {
 		statusCode := http.StatusOK
 
-		if response == nil {
+		if response == nil && len(info.ResponseContent) == 0 {
 			statusCode = http.StatusNoContent
 		}
 
@@ -1029,6 +1298,14 @@ func (g *Generator) SetPathItem(info PathItemInfo) *OperationObj {
 		}
 
 		g.parseResponseObject(operationObj, statusCode, response)
+
+		if len(info.ResponseContent) > 0 {
+			g.setResponseContent(info, operationObj, statusCode, response)
+		}
+	}
+
+	if len(info.RequestContent) > 0 {
+		body = g.setRequestContent(info, operationObj, body)
 	}
 
 	if body != nil {
@@ -1036,7 +1313,7 @@ func (g *Generator) SetPathItem(info PathItemInfo) *OperationObj {
 			operationObj.AddExtendedField("x-request-go-type", refl.GoType(reflect.TypeOf(body)))
 		}
 
-		typeDef := g.ParseDefinition(body)
+		typeDef := g.parseDefinition(body)
 
 		if !typeDef.isEmpty() {
 			param := ParamObj{
@@ -1056,26 +1333,26 @@ func (g *Generator) SetPathItem(info PathItemInfo) *OperationObj {
 		}
 	}
 
-	switch strings.ToUpper(info.Method) {
-	case http.MethodGet:
-		item.Get = operationObj
-	case http.MethodPost:
-		item.Post = operationObj
-	case http.MethodPut:
-		item.Put = operationObj
-	case http.MethodDelete:
-		item.Delete = operationObj
-	case http.MethodOptions:
-		item.Options = operationObj
-	case http.MethodHead:
-		item.Head = operationObj
-	case http.MethodPatch:
-		item.Patch = operationObj
+	g.setExamples(info, operationObj)
+
+	if len(g.reflectErrors) == 0 {
+		setProxy()
 	}
 
+	if errs := g.takeReflectErrors(); len(errs) > 0 {
+		g.restore(snapshot)
+
+		return nil, ReflectError{Method: info.Method, Path: info.Path, Errors: errs}
+	}
+
+	item.setOperation(info.Method, operationObj)
 	g.paths[info.Path] = item
 
-	return operationObj
+	if pattern != info.Path {
+		g.pathPatterns[info.Path] = pattern
+	}
+
+	return operationObj, nil
 }
 
 func (g *Generator) parseResponseObject(operationObj *OperationObj, statusCode int, responseObj interface{}) {
@@ -1084,8 +1361,6 @@ func (g *Generator) parseResponseObject(operationObj *OperationObj, statusCode i
 	}
 
 	if responseObj != nil {
-		schema := g.ParseDefinition(responseObj)
-
 		var desc string
 
 		if withDesc, ok := responseObj.(description); ok {
@@ -1093,15 +1368,56 @@ func (g *Generator) parseResponseObject(operationObj *OperationObj, statusCode i
 		} else {
 			desc = http.StatusText(statusCode)
 		}
-		// since we only response json object
-		// so, type of response object is always object
-		operationObj.Responses[statusCode] = ResponseObj{
+
+		resp := ResponseObj{
 			Description: desc,
-			Schema:      &schema,
+			Headers:     g.parseResponseHeaders(responseObj),
 		}
+
+		// Structure with headers and without JSON fields has no body.
+		if resp.Headers == nil || refl.HasTaggedFields(responseObj, "json") {
+			// since we only response json object
+			// so, type of response object is always object
+			schema := g.parseDefinition(responseObj)
+			resp.Schema = &schema
+		}
+
+		operationObj.Responses[statusCode] = resp
 	} else {
 		operationObj.Responses[statusCode] = ResponseObj{
 			Description: http.StatusText(statusCode),
 		}
 	}
 }
+
+// parseResponseHeaders reflects fields of response structure with "header" tag into response headers.
+func (g *Generator) parseResponseHeaders(response interface{}) map[string]HeaderObj {
+	if _, ok := response.(SchemaDefinition); ok || !refl.HasTaggedFields(response, "header") {
+		return nil
+	}
+
+	_, params := g.parseParameters(response)
+
+	var headers map[string]HeaderObj
+
+	for _, p := range params {
+		if p.In != "header" {
+			continue
+		}
+
+		h := HeaderObj{CommonFields: p.CommonFields, Items: p.Items, CollectionFormat: p.CollectionFormat}
+		h.Title = ""
+
+		if h.CollectionFormat == "multi" {
+			h.CollectionFormat = ""
+		}
+
+		if headers == nil {
+			headers = make(map[string]HeaderObj)
+		}
+
+		headers[p.Name] = h
+	}
+
+	return headers
+}
@@ -1,6 +1,7 @@
 package swgen
 
 import (
+	"errors"
 	"fmt"
 	"mime/multipart"
 	"net/http"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/swaggest/assertjson"
 	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/openapi-go/openapi3"
 )
 
 type Person struct {
@@ -781,3 +783,103 @@ func TestGenerator_SetPathItem_exposer(t *testing.T) {
         	            	  }
         	            	}`), swg, string(swg))
 }
+
+func TestGenerator_TrySetPathItem(t *testing.T) {
+	type (
+		Req struct {
+			Name  string `query:"name" minLength:"three"`
+			Limit int    `query:"limit" required:"maybe"`
+		}
+
+		Resp struct {
+			Title  string       `json:"title" maximum:"ten"`
+			Stream fmt.Stringer `json:"stream"`
+		}
+
+		Resp2 struct {
+			Title string `json:"title"`
+		}
+	)
+
+	reflector := openapi3.Reflector{}
+
+	g := NewGenerator()
+	g.SetOAS3Proxy(&reflector)
+
+	op, err := g.TrySetPathItem(PathItemInfo{
+		Method:   http.MethodGet,
+		Path:     "/items",
+		Request:  new(Req),
+		Response: new(Resp),
+	})
+	assert.Nil(t, op)
+
+	var re ReflectError
+
+	assert.True(t, errors.As(err, &re))
+	assert.Equal(t, http.MethodGet, re.Method)
+	assert.Equal(t, "/items", re.Path)
+	assert.Len(t, re.Errors, 4)
+
+	assert.Equal(t, "github.com/swaggest/swgen.Req", re.Errors[0].GoType)
+	assert.Equal(t, "Name", re.Errors[0].Field)
+	assert.Equal(t, "minLength", re.Errors[0].Tag)
+	assert.Equal(t, "three", re.Errors[0].Value)
+
+	assert.Equal(t, "Limit", re.Errors[1].Field)
+	assert.Equal(t, "required", re.Errors[1].Tag)
+	assert.Equal(t, "maybe", re.Errors[1].Value)
+
+	assert.Equal(t, "github.com/swaggest/swgen.Resp", re.Errors[2].GoType)
+	assert.Equal(t, "Title", re.Errors[2].Field)
+	assert.Equal(t, "maximum", re.Errors[2].Tag)
+
+	assert.Equal(t, "Stream", re.Errors[3].Field)
+	assert.Equal(t, "fmt.Stringer", re.Errors[3].Value)
+	assert.True(t, errors.Is(re.Errors[3], errNonEmptyInterface))
+
+	assert.Contains(t, err.Error(), `failed to reflect GET /items: github.com/swaggest/swgen.Req.Name: failed to parse tag minLength:"three"`)
+
+	_, found := g.paths["/items"]
+	assert.False(t, found, "operation should not be registered")
+	assert.Empty(t, g.definitions, "definitions should not be changed")
+	assert.Empty(t, g.defQueue)
+	assert.Nil(t, reflector.Spec, "OpenAPI 3.0 proxy should not be changed")
+
+	type Other struct {
+		Title string `json:"title"`
+	}
+
+	g.SetPathItem(PathItemInfo{Method: http.MethodGet, Path: "/other", Response: new(Resp2)})
+
+	schemas := len(reflector.Spec.Components.Schemas.MapOfSchemaOrRefValues)
+	definitions := len(g.definitions)
+
+	// Duplicate operation fails in OpenAPI 3.0 proxy after its response is reflected.
+	_, err = g.TrySetPathItem(PathItemInfo{Method: http.MethodGet, Path: "/other", Response: new(Other)})
+	assert.Error(t, err)
+	assert.Len(t, reflector.Spec.Components.Schemas.MapOfSchemaOrRefValues, schemas)
+	assert.Len(t, g.definitions, definitions)
+
+	assert.Panics(t, func() {
+		g.SetPathItem(PathItemInfo{Method: http.MethodGet, Path: "/items", Request: new(Req)})
+	})
+}
+
+func TestGenerator_TryParseDefinition(t *testing.T) {
+	type Bad struct {
+		Count int `json:"count" minimum:"zero" exclusiveMinimum:"yes"`
+	}
+
+	_, err := NewGenerator().TryParseDefinition(new(Bad))
+
+	var re ReflectError
+
+	assert.True(t, errors.As(err, &re))
+	assert.Len(t, re.Errors, 2)
+	assert.Equal(t, "minimum", re.Errors[0].Tag)
+	assert.Equal(t, "exclusiveMinimum", re.Errors[1].Tag)
+
+	_, err = NewGenerator().TryParseDefinition(new(Person))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,77 @@
+package swgen_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/swgen"
+)
+
+type RateLimited struct {
+	Remaining int      `header:"X-RateLimit-Remaining" minimum:"0" description:"Requests left in window."`
+	ETag      string   `header:"ETag"`
+	Warnings  []string `header:"X-Warnings"`
+}
+
+type headedOrder struct {
+	RateLimited
+	ID   int    `json:"id"`
+	Item string `json:"item"`
+}
+
+type createdOrder struct {
+	Location string `header:"Location" format:"uri"`
+}
+
+func TestGenerator_responseHeaders(t *testing.T) {
+	reflector := openapi3.Reflector{}
+
+	g := swgen.NewGenerator()
+	g.SetOAS3Proxy(&reflector)
+	g.SetPathItem(swgen.PathItemInfo{Path: "/orders/{id}", Method: http.MethodGet, Request: new(exampleOrderID),
+		Response: new(headedOrder)})
+	g.SetPathItem(swgen.PathItemInfo{Path: "/orders", Method: http.MethodPost, Request: new(exampleOrder),
+		Response: new(createdOrder), SuccessfulResponseCode: http.StatusCreated})
+
+	data, err := g.GenDocument()
+	require.NoError(t, err)
+
+	var doc swgen.Document
+
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assertjson.EqualMarshal(t, []byte(`{
+	  "description":"OK","schema":{"$ref":"#/definitions/headedOrder"},
+	  "headers":{
+	    "ETag":{"type":"string"},
+	    "X-RateLimit-Remaining":{"description":"Requests left in window.","type":"integer","format":"int32","minimum":0},
+	    "X-Warnings":{"type":"array","items":{"type":"string"},"collectionFormat":"csv"}
+	  }
+	}`), doc.Paths["/orders/{id}"].Get.Responses[http.StatusOK])
+	assertjson.EqualMarshal(t, []byte(`{
+	  "type":"object",
+	  "properties":{"id":{"type":"integer","format":"int32"},"item":{"type":"string"}}
+	}`), doc.Definitions["headedOrder"])
+	assertjson.EqualMarshal(t, []byte(`{
+	  "description":"Created","headers":{"Location":{"type":"string","format":"uri"}}
+	}`), doc.Paths["/orders"].Post.Responses[http.StatusCreated])
+
+	oas3 := g.DocumentOAS3()
+	assertjson.EqualMarshal(t, []byte(`{
+	  "ETag":{"schema":{"type":"string"}},
+	  "X-RateLimit-Remaining":{"description":"Requests left in window.","schema":{"type":"integer","format":"int32","minimum":0}},
+	  "X-Warnings":{"schema":{"type":"array","items":{"type":"string"}}}
+	}`), oas3.Paths["/orders/{id}"].Get.Responses[http.StatusOK].Headers)
+
+	proxied := reflector.Spec.Paths.MapOfPathItemValues["/orders"].MapOfOperationValues["post"]
+	assertjson.EqualMarshal(t, []byte(`{
+	  "Location":{"style":"simple","schema":{"type":"string","format":"uri"}}
+	}`), proxied.Responses.MapOfResponseOrRefValues["201"].Response.Headers)
+}
+
+type exampleOrderID struct {
+	ID int `path:"id"`
+}
@@ -0,0 +1,193 @@
+package swgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v3"
+)
+
+// ResponseValidator checks that HTTP responses conform to operations registered in Generator.
+//
+// It reports undocumented status codes and response bodies that violate documented schemas.
+// Response schemas are compiled again after Generator changes.
+type ResponseValidator struct {
+	operationFinder
+
+	mu       sync.Mutex
+	compiled bool
+	version  uint64 // Cache version of Generator at compilation.
+	err      error
+	schemas  map[responseKey]*jsonschema.Schema
+}
+
+type responseKey struct {
+	path       string
+	method     string
+	statusCode int
+}
+
+// NewResponseValidator creates ResponseValidator.
+func NewResponseValidator(g *Generator) *ResponseValidator {
+	return &ResponseValidator{
+		operationFinder: operationFinder{g: g},
+	}
+}
+
+// CheckResponses is a middleware that validates responses of next handler and calls report on nonconformity.
+//
+// Response is passed to client as is, report receives ValidationError or an error of schema preparation.
+// This middleware buffers response bodies, so it is intended for tests and debug mode.
+func CheckResponses(g *Generator, next http.Handler, report func(r *http.Request, err error)) http.Handler {
+	v := NewResponseValidator(g)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rc := &responseCapture{ResponseWriter: w}
+
+		next.ServeHTTP(rc, r)
+
+		if rc.statusCode == 0 {
+			rc.statusCode = http.StatusOK
+		}
+
+		if err := v.ValidateResponse(r, rc.statusCode, w.Header(), rc.body.Bytes()); err != nil {
+			report(r, err)
+		}
+	})
+}
+
+// ValidateRecorder validates response recorded by httptest.ResponseRecorder for request.
+func (v *ResponseValidator) ValidateRecorder(r *http.Request, rec *httptest.ResponseRecorder) error {
+	return v.ValidateResponse(r, rec.Code, rec.Header(), rec.Body.Bytes())
+}
+
+// ValidateResponse checks status code and body of response to request against matching operation.
+//
+// It returns ValidationError if response is invalid, nil is returned for valid responses and requests
+// that do not match registered operations.
+// Bodies with non-JSON Content-Type are only validated if they contain JSON.
+func (v *ResponseValidator) ValidateResponse(r *http.Request, statusCode int, header http.Header, body []byte) error {
+	op, path, _ := v.FindOperation(r)
+	if op == nil {
+		return nil
+	}
+
+	if _, ok := op.Responses[statusCode]; !ok {
+		return ValidationError{
+			Message: "invalid response",
+			Violations: []Violation{{
+				In:      "response",
+				Name:    "status",
+				Message: fmt.Sprintf("undocumented status code %d", statusCode),
+			}},
+		}
+	}
+
+	schemas, err := v.responseSchemas()
+	if err != nil {
+		return err
+	}
+
+	schema := schemas[responseKey{path: path, method: strings.ToUpper(r.Method), statusCode: statusCode}]
+	if schema == nil {
+		return nil
+	}
+
+	// Content-Type may be sniffed by net/http, so non-JSON type only skips bodies that are not JSON.
+	isJSON := strings.Contains(header.Get("Content-Type"), "json")
+
+	var violations []Violation
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		violations = []Violation{{In: "response", Message: "missing response body"}}
+	} else {
+		var value interface{}
+
+		if err := json.Unmarshal(body, &value); err != nil {
+			if !isJSON {
+				return nil
+			}
+
+			violations = []Violation{{In: "response", Message: "invalid JSON: " + err.Error()}}
+		} else {
+			violations = validateValue(schema, "response", "", value)
+		}
+	}
+
+	if len(violations) > 0 {
+		return ValidationError{Message: "invalid response", Violations: violations}
+	}
+
+	return nil
+}
+
+// responseSchemas returns compiled response schemas, they are compiled again if Generator has changed.
+func (v *ResponseValidator) responseSchemas() (map[responseKey]*jsonschema.Schema, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.g.cacheMu.Lock()
+	version := v.g.cacheVersion
+	v.g.cacheMu.Unlock()
+
+	if !v.compiled || v.version != version {
+		v.compile()
+		v.compiled = true
+		v.version = version
+	}
+
+	return v.schemas, v.err
+}
+
+func (v *ResponseValidator) compile() {
+	v.err = nil
+	v.schemas = make(map[responseKey]*jsonschema.Schema)
+
+	err := v.g.WalkJSONSchemaResponses(func(path, method string, statusCode int, schema map[string]interface{}) {
+		if v.err != nil {
+			return
+		}
+
+		compiled, err := compileJSONSchema(schema)
+		if err != nil {
+			v.err = fmt.Errorf("failed to compile response schema for %s %s %d: %w", method, path, statusCode, err)
+
+			return
+		}
+
+		v.schemas[responseKey{path: path, method: method, statusCode: statusCode}] = compiled
+	})
+	if err != nil && v.err == nil {
+		v.err = err
+	}
+}
+
+// responseCapture passes response to client and keeps a copy of status code and body.
+type responseCapture struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (rc *responseCapture) WriteHeader(statusCode int) {
+	if rc.statusCode == 0 {
+		rc.statusCode = statusCode
+	}
+
+	rc.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rc *responseCapture) Write(data []byte) (int, error) {
+	if rc.statusCode == 0 {
+		rc.statusCode = http.StatusOK
+	}
+
+	rc.body.Write(data)
+
+	return rc.ResponseWriter.Write(data)
+}
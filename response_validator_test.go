@@ -0,0 +1,120 @@
+package swgen_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/swgen"
+)
+
+type checkedItem struct {
+	ID   int    `json:"id" minimum:"1"`
+	Name string `json:"name" required:"true"`
+}
+
+type checkedError struct {
+	Message string `json:"message" required:"true"`
+}
+
+func checkedGenerator() *swgen.Generator {
+	g := swgen.NewGenerator()
+	g.AddDefaultResponse(http.StatusInternalServerError, checkedError{})
+
+	info := swgen.PathItemInfo{Method: http.MethodGet, Path: "/items/{id}", Response: checkedItem{}}
+	info.AddResponse(http.StatusNoContent, nil)
+	g.SetPathItem(info)
+
+	return g
+}
+
+func TestResponseValidator_ValidateRecorder(t *testing.T) {
+	v := swgen.NewResponseValidator(checkedGenerator())
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "application/json")
+	_, _ = rec.Write([]byte(`{"id":1,"name":"foo"}`))
+	assert.NoError(t, v.ValidateRecorder(req, rec))
+
+	rec = httptest.NewRecorder()
+	_, _ = rec.Write([]byte(`{"id":0}`))
+	err := v.ValidateRecorder(req, rec)
+	require.Error(t, err)
+
+	var ve swgen.ValidationError
+	require.True(t, errors.As(err, &ve))
+	assertjson.EqualMarshal(t, []byte(`{
+	  "error":"invalid response",
+	  "errors":[
+		{"in":"response","message":"missing properties: \"name\""},
+		{"in":"response","name":"/id","message":"must be >= 1 but found 0"}
+	  ]
+	}`), ve)
+
+	rec = httptest.NewRecorder()
+	rec.WriteHeader(http.StatusInternalServerError)
+	_, _ = rec.Write([]byte(`{"message":"failed"}`))
+	assert.NoError(t, v.ValidateRecorder(req, rec))
+
+	rec = httptest.NewRecorder()
+	rec.WriteHeader(http.StatusNoContent)
+	assert.NoError(t, v.ValidateRecorder(req, rec))
+
+	rec = httptest.NewRecorder()
+	rec.WriteHeader(http.StatusNotFound)
+	assert.EqualError(t, v.ValidateRecorder(req, rec), "invalid response: response status: undocumented status code 404")
+
+	// Non-JSON bodies are not validated.
+	rec = httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "text/plain")
+	_, _ = rec.Write([]byte(`foo`))
+	assert.NoError(t, v.ValidateRecorder(req, rec))
+
+	// Unknown operations are skipped.
+	assert.NoError(t, v.ValidateRecorder(httptest.NewRequest(http.MethodPost, "/items/1", nil), httptest.NewRecorder()))
+}
+
+func TestResponseValidator_ValidateResponse_pathAdded(t *testing.T) {
+	g := checkedGenerator()
+	v := swgen.NewResponseValidator(g)
+
+	body := []byte(`{"id":0,"name":"foo"}`)
+
+	require.Error(t, v.ValidateResponse(httptest.NewRequest(http.MethodGet, "/items/1", nil), http.StatusOK, nil, body))
+
+	g.SetPathItem(swgen.PathItemInfo{Method: http.MethodGet, Path: "/other/{id}", Response: checkedItem{}})
+
+	err := v.ValidateResponse(httptest.NewRequest(http.MethodGet, "/other/1", nil), http.StatusOK, nil, body)
+	assert.EqualError(t, err, "invalid response: response /id: must be >= 1 but found 0")
+}
+
+func TestCheckResponses(t *testing.T) {
+	var reported []error
+
+	h := swgen.CheckResponses(checkedGenerator(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("fail") != "" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+
+		_, _ = w.Write([]byte(`{"id":1,"name":"foo"}`))
+	}), func(r *http.Request, err error) {
+		reported = append(reported, err)
+	})
+
+	rw := httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/items/1", nil))
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Empty(t, reported)
+
+	rw = httptest.NewRecorder()
+	h.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/items/1?fail=1", nil))
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+	assert.Equal(t, `{"id":1,"name":"foo"}`, rw.Body.String())
+	require.Len(t, reported, 1)
+	assert.EqualError(t, reported[0], "invalid response: response status: undocumented status code 400")
+}
@@ -0,0 +1,134 @@
+package swgen
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// paramGroupPrefix prefixes names of regexp groups that capture path parameters.
+const paramGroupPrefix = "swgenParam"
+
+// operationRouter matches request paths against path templates of registered operations.
+type operationRouter struct {
+	basePath string
+	routes   []route
+}
+
+type route struct {
+	path   string // Path template as registered in Generator, e.g. "/pets/{id}".
+	re     *regexp.Regexp
+	names  []string
+	static int // Length of non-parametrized part of path, more specific routes are matched first.
+}
+
+// newOperationRouter creates router of paths, patterns provide gorilla.Mux-style regexps of path parameters.
+func newOperationRouter(basePath string, paths map[string]PathItem, patterns map[string]string) *operationRouter {
+	r := &operationRouter{
+		basePath: strings.TrimRight(basePath, "/"),
+		routes:   make([]route, 0, len(paths)),
+	}
+
+	for path := range paths {
+		pattern, ok := patterns[path]
+		if !ok {
+			pattern = path
+		}
+
+		rt := newRoute(pattern)
+		rt.path = path
+		r.routes = append(r.routes, rt)
+	}
+
+	sort.Slice(r.routes, func(i, j int) bool {
+		ri, rj := r.routes[i], r.routes[j]
+
+		if len(ri.names) != len(rj.names) {
+			return len(ri.names) < len(rj.names)
+		}
+
+		if ri.static != rj.static {
+			return ri.static > rj.static
+		}
+
+		return ri.path < rj.path
+	})
+
+	return r
+}
+
+func newRoute(path string) route {
+	rt := route{path: path}
+	pattern := strings.Builder{}
+	pattern.WriteString("^")
+
+	pos := 0
+
+	for _, loc := range regexFindPathParameter.FindAllStringSubmatchIndex(path, -1) {
+		pattern.WriteString(regexp.QuoteMeta(path[pos:loc[0]]))
+		rt.static += loc[0] - pos
+
+		group := "(?P<" + paramGroupPrefix + strconv.Itoa(len(rt.names)) + ">"
+		rt.names = append(rt.names, path[loc[2]:loc[3]])
+
+		if loc[4] != -1 { // Gorilla.Mux-style regexp in path.
+			pattern.WriteString(group + path[loc[4]+1:loc[5]] + ")")
+		} else {
+			pattern.WriteString(group + "[^/]+)")
+		}
+
+		pos = loc[1]
+	}
+
+	pattern.WriteString(regexp.QuoteMeta(path[pos:]))
+	pattern.WriteString("$")
+
+	rt.static += len(path) - pos
+	rt.re = regexp.MustCompile(pattern.String())
+
+	return rt
+}
+
+// match returns registered path template and path parameters for request path.
+func (r *operationRouter) match(requestPath string) (string, map[string]string, bool) {
+	if r.basePath != "" {
+		if !strings.HasPrefix(requestPath, r.basePath) {
+			return "", nil, false
+		}
+
+		requestPath = requestPath[len(r.basePath):]
+	}
+
+	for _, rt := range r.routes {
+		m := rt.re.FindStringSubmatch(requestPath)
+		if m == nil {
+			continue
+		}
+
+		params := make(map[string]string, len(rt.names))
+
+		for i, group := range rt.re.SubexpNames() {
+			if !strings.HasPrefix(group, paramGroupPrefix) {
+				continue
+			}
+
+			n, err := strconv.Atoi(group[len(paramGroupPrefix):])
+			if err != nil || n >= len(rt.names) {
+				continue
+			}
+
+			v, err := url.PathUnescape(m[i])
+			if err != nil {
+				v = m[i]
+			}
+
+			params[rt.names[n]] = v
+		}
+
+		return rt.path, params, true
+	}
+
+	return "", nil, false
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Chi adapts chi router, routes of mounted subrouters are walked with full patterns.
+func Chi(r chi.Router) Router {
+	return chiRouter{r: r}
+}
+
+type chiRouter struct {
+	r chi.Router
+}
+
+func (c chiRouter) Handle(method, pattern string, h http.Handler) {
+	c.r.Method(method, pattern, h)
+}
+
+func (c chiRouter) Walk(fn func(method, pattern string) error) error {
+	return chi.Walk(c.r, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		return fn(method, route)
+	})
+}
@@ -0,0 +1,46 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Gorilla adapts gorilla/mux router, routes without handler (e.g. prefixes of subrouters) are not walked.
+func Gorilla(r *mux.Router) Router {
+	return gorillaRouter{r: r}
+}
+
+type gorillaRouter struct {
+	r *mux.Router
+}
+
+func (g gorillaRouter) Handle(method, pattern string, h http.Handler) {
+	g.r.Handle(pattern, h).Methods(method)
+}
+
+func (g gorillaRouter) Walk(fn func(method, pattern string) error) error {
+	return g.r.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		if route.GetHandler() == nil {
+			return nil
+		}
+
+		pattern, err := route.GetPathTemplate()
+		if err != nil {
+			return nil //nolint:nilerr // Routes without path template are not comparable to documentation.
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			return fn("", pattern)
+		}
+
+		for _, method := range methods {
+			if err := fn(method, pattern); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,151 @@
+// Package routes registers HTTP handlers on routers and documents them in swgen.Generator at once.
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/swaggest/swgen"
+)
+
+// Router is an adapter of HTTP router, see ServeMux, Chi and Gorilla.
+type Router interface {
+	// Handle registers handler for HTTP method and path pattern.
+	Handle(method, pattern string, h http.Handler)
+
+	// Walk calls fn for every route of router, empty method stands for route that serves any method.
+	Walk(fn func(method, pattern string) error) error
+}
+
+// Route declares handler of operation together with its documentation.
+type Route struct {
+	// Info documents operation, Method and Path are also used to register handler.
+	// Path can contain gorilla.Mux-style regexps of parameters, e.g. "/pets/{id:[0-9]+}".
+	Info    swgen.PathItemInfo
+	Handler http.Handler
+}
+
+// Endpoint identifies route of router.
+type Endpoint struct {
+	Method  string // Empty for route that serves any method.
+	Pattern string
+}
+
+// String formats endpoint as "METHOD /pattern".
+func (e Endpoint) String() string {
+	method := e.Method
+	if method == "" {
+		method = "ANY"
+	}
+
+	return method + " " + e.Pattern
+}
+
+// RegisterError describes route that failed to be documented by Register.
+type RegisterError struct {
+	// Registered lists routes that were documented before failure, their handlers are registered.
+	Registered []Endpoint
+	Err        error
+}
+
+// Error implements error.
+func (e RegisterError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns error of failed route.
+func (e RegisterError) Unwrap() error {
+	return e.Err
+}
+
+// Register documents routes in Generator and registers their handlers on router under base path of Generator.
+//
+// Nothing is registered if any route misses handler. If route fails to be documented, routes documented
+// before it stay in Generator and their handlers are registered, so that document has no routes without
+// handlers, RegisterError lists such routes.
+func Register(g *swgen.Generator, r Router, routes ...Route) error {
+	for _, rt := range routes {
+		if rt.Handler == nil {
+			return fmt.Errorf("missing handler for %s %s", rt.Info.Method, rt.Info.Path)
+		}
+	}
+
+	var (
+		documented int
+		err        error
+	)
+
+	for _, rt := range routes {
+		if _, err = g.TrySetPathItem(rt.Info); err != nil {
+			break
+		}
+
+		documented++
+	}
+
+	basePath := strings.TrimRight(g.Document().BasePath, "/")
+	registered := make([]Endpoint, 0, documented)
+
+	for _, rt := range routes[:documented] {
+		r.Handle(rt.Info.Method, basePath+rt.Info.Path, rt.Handler)
+		registered = append(registered, Endpoint{Method: rt.Info.Method, Pattern: basePath + rt.Info.Path})
+	}
+
+	if err != nil {
+		return RegisterError{Registered: registered, Err: err}
+	}
+
+	return nil
+}
+
+// Undocumented returns routes of router that have no operation in Generator.
+//
+// Route that serves any method is documented if its path has at least one operation.
+// Route patterns are expected to be prefixed with base path of Generator, as Register does.
+func Undocumented(g *swgen.Generator, r Router) ([]Endpoint, error) {
+	data, err := g.GenDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	var doc swgen.Document
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	basePath := strings.TrimRight(doc.BasePath, "/")
+
+	var res []Endpoint
+
+	err = r.Walk(func(method, pattern string) error {
+		path := normalizePattern(pattern)
+
+		if !strings.HasPrefix(path, basePath) {
+			res = append(res, Endpoint{Method: method, Pattern: pattern})
+
+			return nil
+		}
+
+		ops := doc.Paths[strings.TrimPrefix(path, basePath)].Map()
+
+		if (method == "" && len(ops) == 0) || (method != "" && ops[strings.ToUpper(method)] == nil) {
+			res = append(res, Endpoint{Method: method, Pattern: pattern})
+		}
+
+		return nil
+	})
+
+	return res, err
+}
+
+// regexPathParameter finds path parameters with optional regexp, e.g. "{id}" or "{id:[0-9]+}".
+var regexPathParameter = regexp.MustCompile(`\{([^}:]+)(:[^\/]+)?(?:\})`)
+
+// normalizePattern removes regexps of path parameters.
+func normalizePattern(pattern string) string {
+	return regexPathParameter.ReplaceAllString(pattern, "{$1}")
+}
@@ -0,0 +1,185 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/swgen"
+	"github.com/swaggest/swgen/routes"
+)
+
+type pet struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type petID struct {
+	ID int64 `path:"id"`
+}
+
+func petRoutes(pathValue func(r *http.Request) string) []routes.Route {
+	return []routes.Route{
+		{
+			Info: swgen.PathItemInfo{Method: http.MethodGet, Path: "/pets/{id:[0-9]+}", Request: new(petID), Response: new(pet)},
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprintf(w, `{"id":%s}`, pathValue(r))
+			}),
+		},
+		{
+			Info: swgen.PathItemInfo{Method: http.MethodPost, Path: "/pets", Request: new(pet), Response: new(pet)},
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+			}),
+		},
+	}
+}
+
+func serve(h http.Handler, method, target string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+
+	return rec
+}
+
+func TestRegister(t *testing.T) {
+	health := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	for _, tc := range []struct {
+		name         string
+		setup        func() (routes.Router, http.Handler, func(r *http.Request) string)
+		undocumented []string
+	}{
+		{
+			name: "ServeMux",
+			setup: func() (routes.Router, http.Handler, func(r *http.Request) string) {
+				m := http.NewServeMux()
+				r := routes.ServeMux(m)
+				r.Handle(http.MethodGet, "/health", health)
+
+				return r, m, func(r *http.Request) string { return routes.PathValue(r, "id") }
+			},
+			undocumented: []string{"GET /health"},
+		},
+		{
+			name: "Chi",
+			setup: func() (routes.Router, http.Handler, func(r *http.Request) string) {
+				m := chi.NewRouter()
+				m.Get("/health", health)
+
+				return routes.Chi(m), m, func(r *http.Request) string { return chi.URLParam(r, "id") }
+			},
+			undocumented: []string{"GET /health"},
+		},
+		{
+			name: "Gorilla",
+			setup: func() (routes.Router, http.Handler, func(r *http.Request) string) {
+				m := mux.NewRouter()
+				m.Handle("/health", health)
+
+				return routes.Gorilla(m), m, func(r *http.Request) string { return mux.Vars(r)["id"] }
+			},
+			undocumented: []string{"ANY /health"},
+		},
+	} {
+		tc := tc
+
+		for _, basePath := range []string{"", "/api"} {
+			testRegister(t, tc.name+basePath, basePath, tc.setup, tc.undocumented)
+		}
+	}
+}
+
+func testRegister(
+	t *testing.T,
+	name, basePath string,
+	setup func() (routes.Router, http.Handler, func(r *http.Request) string),
+	expectedUndocumented []string,
+) {
+	t.Helper()
+
+	t.Run(name, func(t *testing.T) {
+		g := swgen.NewGenerator().SetBasePath(basePath)
+		r, h, pathValue := setup()
+
+		require.NoError(t, routes.Register(g, r, petRoutes(pathValue)...))
+
+		data, err := g.GenDocument()
+		require.NoError(t, err)
+
+		var doc swgen.Document
+
+		require.NoError(t, json.Unmarshal(data, &doc))
+		assert.NotNil(t, doc.Paths["/pets/{id}"].Get)
+		assert.NotNil(t, doc.Paths["/pets"].Post)
+
+		rec := serve(h, http.MethodGet, basePath+"/pets/12")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `{"id":12}`, rec.Body.String())
+
+		assert.Equal(t, http.StatusNotFound, serve(h, http.MethodGet, basePath+"/pets/abc").Code)
+		assert.Equal(t, http.StatusCreated, serve(h, http.MethodPost, basePath+"/pets").Code)
+		assert.Equal(t, http.StatusMethodNotAllowed, serve(h, http.MethodDelete, basePath+"/pets").Code)
+
+		undocumented, err := routes.Undocumented(g, r)
+		require.NoError(t, err)
+
+		var names []string
+		for _, e := range undocumented {
+			names = append(names, e.String())
+		}
+
+		assert.Equal(t, expectedUndocumented, names)
+	})
+}
+
+func TestRegister_invalid(t *testing.T) {
+	g := swgen.NewGenerator()
+	m := chi.NewRouter()
+
+	err := routes.Register(g, routes.Chi(m), routes.Route{
+		Info: swgen.PathItemInfo{Method: http.MethodGet, Path: "/pets", Response: new(pet)},
+	})
+	assert.EqualError(t, err, "missing handler for GET /pets")
+	assert.Empty(t, m.Routes())
+}
+
+type invalidPet struct {
+	Name string `json:"name" minLength:"abc"`
+}
+
+func TestRegister_partial(t *testing.T) {
+	g := swgen.NewGenerator().SetBasePath("/api")
+	m := chi.NewRouter()
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	err := routes.Register(g, routes.Chi(m),
+		routes.Route{Info: swgen.PathItemInfo{Method: http.MethodGet, Path: "/pets", Response: new(pet)}, Handler: ok},
+		routes.Route{Info: swgen.PathItemInfo{Method: http.MethodPost, Path: "/pets", Request: new(invalidPet)}, Handler: ok},
+		routes.Route{Info: swgen.PathItemInfo{Method: http.MethodGet, Path: "/owners", Response: new(pet)}, Handler: ok},
+	)
+	require.Error(t, err)
+
+	var re routes.RegisterError
+	require.True(t, errors.As(err, &re))
+	assert.Equal(t, []routes.Endpoint{{Method: http.MethodGet, Pattern: "/api/pets"}}, re.Registered)
+
+	var reflectErr swgen.ReflectError
+	assert.True(t, errors.As(err, &reflectErr))
+
+	// Documented route has handler, failed and following routes are neither documented nor registered.
+	assert.Equal(t, http.StatusOK, serve(m, http.MethodGet, "/api/pets").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(m, http.MethodPost, "/api/pets").Code)
+	assert.Equal(t, http.StatusNotFound, serve(m, http.MethodGet, "/api/owners").Code)
+
+	undocumented, err := routes.Undocumented(g, routes.Chi(m))
+	require.NoError(t, err)
+	assert.Empty(t, undocumented)
+}
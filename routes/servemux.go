@@ -0,0 +1,170 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ServeMux adapts http.ServeMux, it adds method routing and path parameters, see PathValue.
+//
+// Templated path is served by handler of its static prefix, e.g. "/pets/{id}" by "/pets/" subtree.
+// Only routes registered with adapter are walked.
+func ServeMux(mux *http.ServeMux) Router {
+	return &serveMux{mux: mux, dispatchers: make(map[string]*dispatcher)}
+}
+
+type serveMux struct {
+	mux *http.ServeMux
+
+	mu          sync.Mutex
+	endpoints   []Endpoint
+	dispatchers map[string]*dispatcher // dispatchers by patterns of http.ServeMux
+}
+
+type muxRoute struct {
+	method  string
+	re      *regexp.Regexp
+	names   []string
+	handler http.Handler
+}
+
+// dispatcher serves routes that share a pattern of http.ServeMux.
+type dispatcher struct {
+	mu     sync.RWMutex
+	routes []muxRoute
+}
+
+func (s *serveMux) Handle(method, pattern string, h http.Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.endpoints = append(s.endpoints, Endpoint{Method: method, Pattern: pattern})
+
+	muxPattern := pattern
+	if i := strings.Index(pattern, "{"); i != -1 {
+		muxPattern = pattern[:strings.LastIndex(pattern[:i], "/")+1]
+	}
+
+	d, ok := s.dispatchers[muxPattern]
+	if !ok {
+		d = &dispatcher{}
+		s.dispatchers[muxPattern] = d
+		s.mux.Handle(muxPattern, d)
+	}
+
+	d.add(newMuxRoute(strings.ToUpper(method), pattern, h))
+}
+
+func (s *serveMux) Walk(fn func(method, pattern string) error) error {
+	s.mu.Lock()
+	endpoints := append([]Endpoint(nil), s.endpoints...)
+	s.mu.Unlock()
+
+	for _, e := range endpoints {
+		if err := fn(e.Method, e.Pattern); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newMuxRoute(method, pattern string, h http.Handler) muxRoute {
+	rt := muxRoute{method: method, handler: h}
+	re := strings.Builder{}
+	re.WriteString("^")
+
+	pos := 0
+
+	for _, loc := range regexPathParameter.FindAllStringSubmatchIndex(pattern, -1) {
+		re.WriteString(regexp.QuoteMeta(pattern[pos:loc[0]]))
+		rt.names = append(rt.names, pattern[loc[2]:loc[3]])
+
+		if loc[4] != -1 {
+			re.WriteString("(" + pattern[loc[4]+1:loc[5]] + ")")
+		} else {
+			re.WriteString("([^/]+)")
+		}
+
+		pos = loc[1]
+	}
+
+	re.WriteString(regexp.QuoteMeta(pattern[pos:]) + "$")
+	rt.re = regexp.MustCompile(re.String())
+
+	return rt
+}
+
+// add keeps routes with less parameters first, so that static paths take precedence.
+func (d *dispatcher) add(rt muxRoute) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.routes = append(d.routes, rt)
+
+	sort.SliceStable(d.routes, func(i, j int) bool {
+		return len(d.routes[i].names) < len(d.routes[j].names)
+	})
+}
+
+func (d *dispatcher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var allowed []string
+
+	for _, rt := range d.routes {
+		m := rt.re.FindStringSubmatch(r.URL.Path)
+		if m == nil {
+			continue
+		}
+
+		if rt.method != r.Method {
+			allowed = append(allowed, rt.method)
+
+			continue
+		}
+
+		if len(rt.names) > 0 {
+			values := make(map[string]string, len(rt.names))
+
+			for i, name := range rt.names {
+				v, err := url.PathUnescape(m[i+1])
+				if err != nil {
+					v = m[i+1]
+				}
+
+				values[name] = v
+			}
+
+			r = r.WithContext(context.WithValue(r.Context(), pathValuesKey{}, values))
+		}
+
+		rt.handler.ServeHTTP(w, r)
+
+		return
+	}
+
+	if len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	http.NotFound(w, r)
+}
+
+type pathValuesKey struct{}
+
+// PathValue returns value of path parameter of request served by ServeMux adapter.
+func PathValue(r *http.Request, name string) string {
+	values, _ := r.Context().Value(pathValuesKey{}).(map[string]string)
+
+	return values[name]
+}
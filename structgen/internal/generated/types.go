@@ -0,0 +1,34 @@
+// Code generated by github.com/swaggest/swgen/structgen. DO NOT EDIT.
+
+// Package generated contains types of API.
+package generated
+
+import "time"
+
+// Owner has pets.
+type Owner struct {
+	Name  string `json:"name" required:"true"`
+	Phone string `json:"phone,omitempty" pattern:"^\\+[0-9]+$"`
+}
+
+// Pet is a pet in store.
+//
+// Pets are identified by ID.
+type Pet struct {
+	Attributes map[string]int32 `json:"attributes,omitempty" maxProperties:"10" minProperties:"1"`
+	Born       time.Time        `json:"born,omitempty"`
+	Email      string           `json:"email,omitempty" title:"Contact" format:"email"`
+	Friends    []Pet            `json:"friends,omitempty"`
+	ID         int64            `json:"id" required:"true" minimum:"1"`
+	Legs       int32            `json:"legs,omitempty" minimum:"0" enum:"[2,4]" default:"4"`
+	Name       string           `json:"name" required:"true" pattern:"^[a-z]+$" maxLength:"64" minLength:"1"`
+	Owner      Owner            `json:"owner,omitempty"`
+	Price      float64          `json:"price,omitempty" maximum:"1000" exclusiveMaximum:"true"`
+	Status     string           `json:"status,omitempty" description:"Sale status." enum:"[\"available\",\"sold\"]" default:"available"`
+	Tags       []string         `json:"tags,omitempty" maxItems:"10" minItems:"1" uniqueItems:"true"`
+	Vaccinated bool             `json:"vaccinated,omitempty" default:"true"`
+	Weight     float32          `json:"weight,omitempty" multipleOf:"0.5" minimum:"0" exclusiveMinimum:"true"`
+}
+
+// Tags is a named list.
+type Tags []string
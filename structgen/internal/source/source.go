@@ -0,0 +1,32 @@
+// Package source contains types to test round trip of generated structures.
+package source
+
+import "time"
+
+// Pet is a pet in store.
+//
+// Pets are identified by ID.
+type Pet struct {
+	ID         int64          `json:"id" required:"true" minimum:"1"`
+	Name       string         `json:"name" required:"true" minLength:"1" maxLength:"64" pattern:"^[a-z]+$"`
+	Status     string         `json:"status,omitempty" enum:"available,sold" default:"available" description:"Sale status."`
+	Tags       []string       `json:"tags,omitempty" minItems:"1" maxItems:"10" uniqueItems:"true"`
+	Weight     float32        `json:"weight" minimum:"0" exclusiveMinimum:"true" multipleOf:"0.5"`
+	Price      float64        `json:"price" maximum:"1000" exclusiveMaximum:"true"`
+	Legs       uint8          `json:"legs" enum:"[2,4]" default:"4"`
+	Born       time.Time      `json:"born"`
+	Email      string         `json:"email" format:"email" title:"Contact"`
+	Vaccinated bool           `json:"vaccinated" default:"true"`
+	Owner      *Owner         `json:"owner"`
+	Attributes map[string]int `json:"attributes" minProperties:"1" maxProperties:"10"`
+	Friends    []Pet          `json:"friends"`
+}
+
+// Owner has pets.
+type Owner struct {
+	Name  string `json:"name" required:"true"`
+	Phone string `json:"phone" pattern:"^\\+[0-9]+$"`
+}
+
+// Tags is a named list.
+type Tags []string
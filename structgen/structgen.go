@@ -0,0 +1,363 @@
+// Package structgen generates Go structures from Swagger 2.0 definitions, it is a reverse of swgen.ParseDefinition.
+package structgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/swgen"
+	"github.com/swaggest/swgen/internal/naming"
+)
+
+// Config controls structures generation.
+type Config struct {
+	// PackageName is a name of generated package, default "types".
+	PackageName string
+}
+
+// GenerateJSON renders Go types for definitions of swagger.json.
+func GenerateJSON(data []byte, cfg Config) ([]byte, error) {
+	var doc swgen.Document
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	return Generate(doc, cfg)
+}
+
+// Generate renders Go types for definitions of Swagger 2.0 document.
+//
+// Types are named after "x-go-type" or definition name, fields are named after "x-go-property-names" if available.
+// Schema keywords are rendered as field tags understood by swgen, so that parsing of generated types
+// reproduces definitions. Constraints of array items and composition keywords are not rendered.
+func Generate(doc swgen.Document, cfg Config) ([]byte, error) {
+	if cfg.PackageName == "" {
+		cfg.PackageName = "types"
+	}
+
+	g := generator{
+		doc:      doc,
+		typeName: make(map[string]string, len(doc.Definitions)),
+		reserved: make(map[string]bool, len(doc.Definitions)),
+	}
+
+	names := naming.SortedKeys(doc.Definitions)
+
+	// Type names are allocated before rendering to resolve references.
+	for _, name := range names {
+		g.typeName[name] = g.reserve(definitionTypeName(name, doc.Definitions[name]))
+	}
+
+	for _, name := range names {
+		g.writeType(g.typeName[name], doc.Definitions[name])
+	}
+
+	out := bytes.NewBuffer(nil)
+
+	out.WriteString("// Code generated by github.com/swaggest/swgen/structgen. DO NOT EDIT.\n\n")
+	out.WriteString("// Package " + cfg.PackageName + " contains types of")
+
+	if doc.Info.Title != "" {
+		out.WriteString(" " + doc.Info.Title)
+	} else {
+		out.WriteString(" API")
+	}
+
+	out.WriteString(".\npackage " + cfg.PackageName + "\n\n")
+
+	if g.usesTime {
+		out.WriteString("import \"time\"\n\n")
+	}
+
+	out.Write(g.types.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return out.Bytes(), fmt.Errorf("failed to format generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+type generator struct {
+	doc      swgen.Document
+	typeName map[string]string // Go type name by definition name.
+	reserved map[string]bool   // Allocated Go type names.
+	usesTime bool
+	types    bytes.Buffer
+}
+
+// definitionTypeName prefers type name of "x-go-type" and falls back to definition name.
+func definitionTypeName(name string, def swgen.SchemaObj) string {
+	if def.GoType != "" {
+		goType := strings.SplitN(def.GoType, "::", 2)[0]
+		goType = goType[strings.LastIndex(goType, ".")+1:]
+
+		if token.IsIdentifier(goType) {
+			return goType
+		}
+	}
+
+	if token.IsIdentifier(name) {
+		return name
+	}
+
+	return naming.Exported(name)
+}
+
+func (g *generator) reserve(name string) string {
+	unique := name
+
+	for i := 2; g.reserved[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+
+	g.reserved[unique] = true
+
+	return unique
+}
+
+func (g *generator) writeType(name string, s swgen.SchemaObj) {
+	if s.Description != "" {
+		for _, line := range strings.Split(strings.TrimSpace(s.Description), "\n") {
+			g.types.WriteString(strings.TrimRight("// "+line, " ") + "\n")
+		}
+	}
+
+	if s.Type == "object" && s.AdditionalProperties == nil {
+		fmt.Fprintf(&g.types, "type %s %s\n\n", name, g.structType(name, s))
+
+		return
+	}
+
+	fmt.Fprintf(&g.types, "type %s %s\n\n", name, g.goType(name, s, ""))
+}
+
+func (g *generator) structType(name string, s swgen.SchemaObj) string {
+	required := make(map[string]bool, len(s.Required))
+	for _, r := range s.Required {
+		required[r] = true
+	}
+
+	res := bytes.NewBufferString("struct {\n")
+	fieldNames := make(map[string]bool, len(s.Properties))
+
+	for _, prop := range naming.SortedKeys(s.Properties) {
+		schema := s.Properties[prop]
+
+		fieldName := s.GoPropertyNames[prop]
+		if !token.IsIdentifier(fieldName) || !token.IsExported(fieldName) {
+			fieldName = naming.Exported(prop)
+		}
+
+		base := fieldName
+		for i := 2; fieldNames[fieldName]; i++ {
+			fieldName = base + strconv.Itoa(i)
+		}
+
+		fieldNames[fieldName] = true
+
+		fmt.Fprintf(res, "%s %s %s\n", fieldName, g.goType(name+fieldName, schema, s.GoPropertyTypes[prop]),
+			fieldTag(prop, schema, required[prop]))
+	}
+
+	res.WriteString("}")
+
+	return res.String()
+}
+
+var builtinTypes = map[string]string{
+	"bool": "boolean", "string": "string",
+	"int": "integer", "int8": "integer", "int16": "integer", "int32": "integer", "int64": "integer",
+	"uint": "integer", "uint8": "integer", "uint16": "integer", "uint32": "integer", "uint64": "integer",
+	"float32": "number", "float64": "number",
+}
+
+// goType returns Go type expression for schema, inline objects are generated as named types.
+func (g *generator) goType(name string, s swgen.SchemaObj, hint string) string {
+	if s.Ref != "" {
+		if t, ok := g.typeName[strings.TrimPrefix(s.Ref, "#/definitions/")]; ok {
+			return t
+		}
+
+		return "interface{}"
+	}
+
+	// Builtin type of original field is reused if it matches schema type.
+	if jsonType, ok := builtinTypes[hint]; ok && jsonType == s.Type {
+		return hint
+	}
+
+	switch s.Type {
+	case "array":
+		if s.Items == nil {
+			return "[]interface{}"
+		}
+
+		return "[]" + g.goType(name+"Item", *s.Items, "")
+	case "object":
+		if s.AdditionalProperties != nil {
+			return "map[string]" + g.goType(name+"Value", *s.AdditionalProperties, "")
+		}
+
+		if len(s.Properties) == 0 {
+			return "map[string]interface{}"
+		}
+
+		typeName := g.reserve(name)
+		g.writeType(typeName, s)
+
+		return typeName
+	case "string":
+		if s.Format == "date-time" {
+			g.usesTime = true
+
+			return "time.Time"
+		}
+
+		return "string"
+	case "integer":
+		if s.Format == "int32" {
+			return "int32"
+		}
+
+		return "int64"
+	case "number":
+		if s.Format == "float" {
+			return "float32"
+		}
+
+		return "float64"
+	case "boolean":
+		return "bool"
+	}
+
+	return "interface{}"
+}
+
+// impliedFormats lists formats that are reflected from Go types and do not need a tag.
+var impliedFormats = map[string]bool{
+	"int32": true, "int64": true, "float": true, "double": true, "date-time": true,
+}
+
+// fieldTag renders struct tag with schema keywords.
+func fieldTag(prop string, s swgen.SchemaObj, required bool) string {
+	jsonTag := prop
+	if !required {
+		jsonTag += ",omitempty"
+	}
+
+	tags := []string{"json:" + strconv.Quote(jsonTag)}
+
+	add := func(name, value string) {
+		tags = append(tags, name+":"+strconv.Quote(value))
+	}
+
+	if required {
+		add("required", "true")
+	}
+
+	if s.Title != "" {
+		add("title", s.Title)
+	}
+
+	if s.Description != "" {
+		add("description", s.Description)
+	}
+
+	if s.Format != "" && !impliedFormats[s.Format] {
+		add("format", s.Format)
+	}
+
+	if s.Pattern != "" {
+		add("pattern", s.Pattern)
+	}
+
+	for _, t := range []struct {
+		name  string
+		value *int64
+	}{
+		{"maxLength", s.MaxLength},
+		{"minLength", s.MinLength},
+		{"maxItems", s.MaxItems},
+		{"minItems", s.MinItems},
+		{"maxProperties", s.MaxProperties},
+		{"minProperties", s.MinProperties},
+	} {
+		if t.value != nil {
+			add(t.name, strconv.FormatInt(*t.value, 10))
+		}
+	}
+
+	if s.MultipleOf != 0 {
+		add("multipleOf", formatFloat(s.MultipleOf))
+	}
+
+	if s.Maximum != nil {
+		add("maximum", formatFloat(*s.Maximum))
+	}
+
+	if s.Minimum != nil {
+		add("minimum", formatFloat(*s.Minimum))
+	}
+
+	for _, t := range []struct {
+		name  string
+		value bool
+	}{
+		{"exclusiveMaximum", s.ExclusiveMaximum},
+		{"exclusiveMinimum", s.ExclusiveMinimum},
+		{"uniqueItems", s.UniqueItems},
+	} {
+		if t.value {
+			add(t.name, "true")
+		}
+	}
+
+	if len(s.Enum.Enum) > 0 {
+		if enum, err := json.Marshal(s.Enum.Enum); err == nil {
+			add("enum", string(enum))
+		}
+	}
+
+	if s.Default != nil {
+		add("default", formatDefault(s.Default))
+	}
+
+	tag := strings.Join(tags, " ")
+
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+
+	return "`" + tag + "`"
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// formatDefault renders default value in a form that is parsed back by swgen.
+func formatDefault(v interface{}) string {
+	switch d := v.(type) {
+	case string:
+		return d
+	case float64:
+		return formatFloat(d)
+	case bool:
+		return strconv.FormatBool(d)
+	}
+
+	j, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(j)
+}
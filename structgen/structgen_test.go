@@ -0,0 +1,95 @@
+package structgen_test
+
+import (
+	"flag"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/swgen"
+	"github.com/swaggest/swgen/structgen"
+	"github.com/swaggest/swgen/structgen/internal/generated"
+	"github.com/swaggest/swgen/structgen/internal/source"
+)
+
+// update regenerates golden file instead of comparing with it, e.g. "go test . -update".
+var update = flag.Bool("update", false, "update golden file")
+
+func sourceDocument(t *testing.T) []byte {
+	t.Helper()
+
+	g := swgen.NewGenerator()
+	require.NoError(t, g.ReflectComments("internal/source"))
+
+	g.ParseDefinition(source.Pet{})
+	g.ParseDefinition(source.Tags{})
+
+	data, err := g.GenDocument()
+	require.NoError(t, err)
+
+	return data
+}
+
+func TestGenerateJSON(t *testing.T) {
+	src, err := structgen.GenerateJSON(sourceDocument(t), structgen.Config{PackageName: "generated"})
+	require.NoError(t, err)
+
+	expected, err := ioutil.ReadFile("internal/generated/types.go")
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, ioutil.WriteFile("internal/generated/types.go", src, 0o600))
+
+		return
+	}
+
+	assert.Equal(t, string(expected), string(src), "run tests with -update to regenerate internal/generated/types.go")
+}
+
+func TestGenerateJSON_roundTrip(t *testing.T) {
+	g := swgen.NewGenerator()
+	require.NoError(t, g.ReflectComments("internal/generated"))
+
+	g.ParseDefinition(generated.Pet{})
+	g.ParseDefinition(generated.Tags{})
+
+	data, err := g.GenDocument()
+	require.NoError(t, err)
+
+	assertjson.Equal(t, sourceDocument(t), data)
+}
+
+func TestGenerate_goTypes(t *testing.T) {
+	type pet struct {
+		Identifier uint16 `json:"id"`
+		Inline     struct {
+			Name string `json:"name"`
+		} `json:"inline"`
+	}
+
+	g := swgen.NewGenerator().ReflectGoTypes(true)
+	g.ParseDefinition(pet{})
+
+	data, err := g.GenDocument()
+	require.NoError(t, err)
+
+	src, err := structgen.GenerateJSON(data, structgen.Config{})
+	require.NoError(t, err)
+
+	assert.Equal(t, `// Code generated by github.com/swaggest/swgen/structgen. DO NOT EDIT.
+
+// Package types contains types of API.
+package types
+
+type pet struct {
+	Identifier uint16    `+"`"+`json:"id,omitempty" minimum:"0"`+"`"+`
+	Inline     petInline `+"`"+`json:"inline,omitempty"`+"`"+`
+}
+
+type petInline struct {
+	Name string `+"`"+`json:"name,omitempty"`+"`"+`
+}
+`, string(src))
+}
@@ -0,0 +1,66 @@
+package swgen
+
+// swagger2PathItem adapts operations of path item to Swagger 2.0,
+// it applies cookie parameter policy, flattens deepObject parameters and renders arrays of files.
+func (g *Generator) swagger2PathItem(item PathItem) PathItem {
+	for method, op := range item.Map() {
+		item.setOperation(method, g.swagger2Operation(op))
+	}
+
+	return item
+}
+
+// swagger2Operation returns a copy of operation with cookie parameters dropped or rendered as headers,
+// deepObject parameters flattened and arrays of files rendered as files with "x-multiple",
+// operation is returned as is if it needs no changes.
+func (g *Generator) swagger2Operation(op *OperationObj) *OperationObj {
+	cookies := g.cookieParamPolicy == CookieParamsDrop || g.cookieParamPolicy == CookieParamsExtension
+	adapted := false
+
+	for _, p := range op.Parameters {
+		if p.style == styleDeepObject || isFileArray(p) || (cookies && p.In == "cookie") {
+			adapted = true
+
+			break
+		}
+	}
+
+	if !adapted {
+		return op
+	}
+
+	res := *op
+	res.Parameters = make([]ParamObj, 0, len(op.Parameters))
+
+	for _, p := range op.Parameters {
+		if p.style == styleDeepObject {
+			res.Parameters = append(res.Parameters,
+				flattenDeepObject(p, g.doc.Definitions, p.CollectionFormat)...)
+
+			continue
+		}
+
+		if isFileArray(p) {
+			p = swagger2FileArray(p)
+		}
+
+		if cookies && p.In == "cookie" {
+			if g.cookieParamPolicy == CookieParamsDrop {
+				continue
+			}
+
+			data := make(map[string]interface{}, len(p.data)+1)
+			for k, v := range p.data {
+				data[k] = v
+			}
+
+			data[xIn] = p.In
+			p.data = data
+			p.In = "header"
+		}
+
+		res.Parameters = append(res.Parameters, p)
+	}
+
+	return &res
+}
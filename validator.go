@@ -0,0 +1,556 @@
+package swgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v3"
+)
+
+// defaultMaxMemory is a limit of multipart form data kept in memory.
+const defaultMaxMemory = 32 << 20
+
+// regexIntegerRat finds integers formatted as rational numbers (e.g. "100/1") in validation messages.
+var regexIntegerRat = regexp.MustCompile(`\b(-?\d+)/1\b`)
+
+// Violation describes a single invalid field.
+type Violation struct {
+	In      string `json:"in"`             // "path", "query", "header", "cookie", "formData", "body" or "response".
+	Name    string `json:"name,omitempty"` // Parameter name or JSON pointer within body.
+	Message string `json:"message"`
+}
+
+// ValidationError lists all violations found in HTTP message.
+type ValidationError struct {
+	Message    string      `json:"error"`
+	Violations []Violation `json:"errors"`
+}
+
+// Error implements error.
+func (e ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+
+	for _, v := range e.Violations {
+		loc := v.In
+		if v.Name != "" {
+			loc += " " + v.Name
+		}
+
+		msgs = append(msgs, loc+": "+v.Message)
+	}
+
+	return e.Message + ": " + strings.Join(msgs, ", ")
+}
+
+// RequestValidator validates HTTP requests against operations registered in Generator.
+//
+// Operations should be registered before first validation.
+type RequestValidator struct {
+	operationFinder
+
+	mu  sync.Mutex
+	ops map[*OperationObj]*operationValidator
+}
+
+// operationFinder lazily builds router of operations registered in Generator.
+//
+// Router and operations are a snapshot that is rebuilt after Generator changes.
+type operationFinder struct {
+	g *Generator
+
+	mu      sync.Mutex
+	router  *operationRouter
+	ops     map[string]map[string]*OperationObj // Operations by path and method.
+	version uint64                              // Cache version of Generator at snapshot.
+}
+
+type operationValidator struct {
+	params      map[int]*jsonschema.Schema // Compiled schemas by index of parameter.
+	body        *jsonschema.Schema
+	definitions map[string]SchemaObj // Definitions to decode deepObject parameters.
+}
+
+// NewRequestValidator creates RequestValidator.
+func NewRequestValidator(g *Generator) *RequestValidator {
+	return &RequestValidator{
+		operationFinder: operationFinder{g: g},
+		ops:             make(map[*OperationObj]*operationValidator),
+	}
+}
+
+// ValidateRequests is a middleware that rejects requests which do not conform to registered operations.
+//
+// Invalid request receives 400 Bad Request with ValidationError in JSON body,
+// requests that do not match any operation are passed to next handler as is.
+func ValidateRequests(g *Generator, next http.Handler) http.Handler {
+	v := NewRequestValidator(g)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := v.ValidateRequest(r)
+		if err == nil {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		var ve ValidationError
+		if !errors.As(err, &ve) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+
+		_ = json.NewEncoder(w).Encode(ve) //nolint:errchkjson // Error can not be reported to client.
+	})
+}
+
+// FindOperation returns registered operation with path template and path parameters matching request.
+func (f *operationFinder) FindOperation(r *http.Request) (op *OperationObj, path string, pathParams map[string]string) {
+	router, ops := f.snapshot()
+
+	path, pathParams, found := router.match(r.URL.Path)
+	if !found {
+		return nil, "", nil
+	}
+
+	op = ops[path][strings.ToUpper(r.Method)]
+
+	return op, path, pathParams
+}
+
+// pathOperations returns operations by method of registered path template.
+func (f *operationFinder) pathOperations(path string) map[string]*OperationObj {
+	_, ops := f.snapshot()
+
+	return ops[path]
+}
+
+// snapshot returns router and operations, they are rebuilt under Generator lock if Generator has changed.
+func (f *operationFinder) snapshot() (*operationRouter, map[string]map[string]*OperationObj) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.g.mu.Lock()
+	defer f.g.mu.Unlock()
+
+	f.g.cacheMu.Lock()
+	version := f.g.cacheVersion
+	f.g.cacheMu.Unlock()
+
+	if f.router == nil || f.version != version {
+		f.router = newOperationRouter(f.g.doc.BasePath, f.g.paths, f.g.pathPatterns)
+		f.ops = make(map[string]map[string]*OperationObj, len(f.g.paths))
+		f.version = version
+
+		for path, item := range f.g.paths {
+			f.ops[path] = item.Map()
+		}
+	}
+
+	return f.router, f.ops
+}
+
+// ValidateRequest checks request parameters and body against matching operation.
+//
+// It returns ValidationError if request is invalid, nil is returned for valid requests and requests
+// that do not match registered operations. Request body is restored for further reading.
+func (v *RequestValidator) ValidateRequest(r *http.Request) error {
+	op, _, pathParams := v.FindOperation(r)
+	if op == nil {
+		return nil
+	}
+
+	ov, err := v.operationValidator(op)
+	if err != nil {
+		return err
+	}
+
+	var violations []Violation
+
+	if hasParamIn(op, "formData") {
+		if err := parseForm(r); err != nil {
+			violations = append(violations, Violation{In: "formData", Message: err.Error()})
+		}
+	}
+
+	for i, p := range op.Parameters {
+		if p.In == "body" {
+			continue
+		}
+
+		if p.style == styleDeepObject {
+			violations = append(violations, ov.deepObjectViolations(r, i, p)...)
+
+			continue
+		}
+
+		values, found := paramValues(r, p, pathParams)
+		if !found {
+			if p.Required {
+				violations = append(violations, Violation{In: p.In, Name: p.Name, Message: "missing value"})
+			}
+
+			continue
+		}
+
+		if isFileParam(p) {
+			// Field named like a file may come in url-encoded form that has no files.
+			if r.MultipartForm == nil {
+				violations = append(violations, Violation{In: p.In, Name: p.Name,
+					Message: "file must be sent in multipart/form-data request"})
+
+				continue
+			}
+
+			violations = append(violations, fileViolations(p, r.MultipartForm.File[p.Name])...)
+
+			continue
+		}
+
+		value, err := decodeParamValue(p, values)
+		if p.partSchema != nil {
+			err = json.Unmarshal([]byte(values[0]), &value)
+		}
+
+		if err != nil {
+			violations = append(violations, Violation{In: p.In, Name: p.Name, Message: err.Error()})
+
+			continue
+		}
+
+		violations = append(violations, validateValue(ov.params[i], p.In, p.Name, value)...)
+	}
+
+	if ov.body != nil {
+		bv, err := v.validateBody(r, op, ov.body)
+		if err != nil {
+			return err
+		}
+
+		violations = append(violations, bv...)
+	}
+
+	if len(violations) > 0 {
+		return ValidationError{Message: "invalid request", Violations: violations}
+	}
+
+	return nil
+}
+
+// deepObjectViolations decodes and validates deepObject parameter, e.g. "filter[status]=open".
+func (ov *operationValidator) deepObjectViolations(r *http.Request, i int, p ParamObj) []Violation {
+	tree, found := deepObjectTree(r.URL.Query(), p.Name)
+	if !found {
+		if p.Required {
+			return []Violation{{In: p.In, Name: p.Name, Message: "missing value"}}
+		}
+
+		return nil
+	}
+
+	value, err := deepObjectValue(*p.Schema, ov.definitions, tree)
+	if err != nil {
+		return []Violation{{In: p.In, Name: p.Name, Message: err.Error()}}
+	}
+
+	return validateValue(ov.params[i], p.In, p.Name, value)
+}
+
+func (v *RequestValidator) validateBody(r *http.Request, op *OperationObj, schema *jsonschema.Schema) ([]Violation, error) {
+	var body []byte
+
+	if r.Body != nil {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+
+		if err := r.Body.Close(); err != nil {
+			return nil, err
+		}
+
+		body = b
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		for _, p := range op.Parameters {
+			if p.In == "body" && p.Required {
+				return []Violation{{In: "body", Message: "missing request body"}}, nil
+			}
+		}
+
+		return nil, nil
+	}
+
+	var value interface{}
+
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []Violation{{In: "body", Message: "invalid JSON: " + err.Error()}}, nil
+	}
+
+	return validateValue(schema, "body", "", value), nil
+}
+
+func (v *RequestValidator) operationValidator(op *OperationObj) (*operationValidator, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if ov, ok := v.ops[op]; ok {
+		return ov, nil
+	}
+
+	ov := &operationValidator{params: make(map[int]*jsonschema.Schema)}
+
+	for i, p := range op.Parameters {
+		if isFileParam(p) {
+			continue
+		}
+
+		if p.partSchema != nil {
+			p.Schema = p.partSchema
+		}
+
+		if p.style == styleDeepObject && ov.definitions == nil {
+			ov.definitions = v.g.definitions.GenDefinitions()
+		}
+
+		schema, err := v.g.ParamJSONSchema(p)
+		if err != nil {
+			return nil, err
+		}
+
+		compiled, err := compileJSONSchema(schema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile schema of %s parameter %s: %w", p.In, p.Name, err)
+		}
+
+		if p.In == "body" {
+			ov.body = compiled
+		} else {
+			ov.params[i] = compiled
+		}
+	}
+
+	v.ops[op] = ov
+
+	return ov, nil
+}
+
+// compileJSONSchema prepares JSON Schema (draft-04) for validation.
+func compileJSONSchema(schema map[string]interface{}) (*jsonschema.Schema, error) {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	c := jsonschema.NewCompiler()
+	c.Draft = jsonschema.Draft4
+
+	if err := c.AddResource("schema.json", bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+
+	return c.Compile("schema.json")
+}
+
+// validateValue checks value against schema and converts errors to violations.
+func validateValue(schema *jsonschema.Schema, in, name string, value interface{}) []Violation {
+	if schema == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return []Violation{{In: in, Name: name, Message: err.Error()}}
+	}
+
+	err = schema.Validate(bytes.NewReader(data))
+	if err == nil {
+		return nil
+	}
+
+	var ve *jsonschema.ValidationError
+	if !errors.As(err, &ve) {
+		return []Violation{{In: in, Name: name, Message: err.Error()}}
+	}
+
+	var (
+		violations []Violation
+		walk       func(e *jsonschema.ValidationError)
+	)
+
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			n := name + strings.TrimPrefix(e.InstancePtr, "#")
+			msg := regexIntegerRat.ReplaceAllString(e.Message, "$1")
+			violations = append(violations, Violation{In: in, Name: n, Message: msg})
+
+			return
+		}
+
+		for _, c := range e.Causes {
+			walk(c)
+		}
+	}
+
+	walk(ve)
+
+	// Causes are reported in unspecified order.
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Name < violations[j].Name
+	})
+
+	return violations
+}
+
+func hasParamIn(op *OperationObj, in string) bool {
+	for _, p := range op.Parameters {
+		if p.In == in {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseForm(r *http.Request) error {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), mimeMultipartForm) {
+		if r.MultipartForm != nil {
+			return nil
+		}
+
+		return r.ParseMultipartForm(defaultMaxMemory)
+	}
+
+	return r.ParseForm()
+}
+
+// paramValues returns raw values of parameter from request.
+func paramValues(r *http.Request, p ParamObj, pathParams map[string]string) ([]string, bool) {
+	var values []string
+
+	switch p.In {
+	case "path":
+		if v, ok := pathParams[p.Name]; ok {
+			values = []string{v}
+		}
+	case "query":
+		values = r.URL.Query()[p.Name]
+	case "header":
+		values = r.Header[http.CanonicalHeaderKey(p.Name)]
+	case "cookie":
+		if c, err := r.Cookie(p.Name); err == nil {
+			values = []string{c.Value}
+		}
+	case "formData":
+		if r.MultipartForm != nil {
+			if isFileParam(p) {
+				return nil, len(r.MultipartForm.File[p.Name]) > 0
+			}
+
+			values = r.MultipartForm.Value[p.Name]
+		} else {
+			values = r.PostForm[p.Name]
+		}
+	}
+
+	return values, len(values) > 0
+}
+
+// decodeParamValue converts raw parameter values to JSON value according to parameter type.
+func decodeParamValue(p ParamObj, values []string) (interface{}, error) {
+	if p.Type != "array" {
+		return decodeScalar(p.Type, values[0])
+	}
+
+	if p.CollectionFormat != "multi" {
+		values = splitCollection(values[0], p.CollectionFormat)
+	}
+
+	return decodeItems(p.Items, values)
+}
+
+// decodeItems converts raw values of array items, nested arrays are split with their collection format.
+func decodeItems(items *ParamItemObj, values []string) ([]interface{}, error) {
+	res := make([]interface{}, 0, len(values))
+
+	for _, v := range values {
+		var (
+			item interface{}
+			err  error
+		)
+
+		switch {
+		case items == nil:
+			item = v
+		case items.Type == "array":
+			item, err = decodeItems(items.Items, splitCollection(v, items.CollectionFormat))
+		default:
+			item, err = decodeScalar(items.Type, v)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, item)
+	}
+
+	return res, nil
+}
+
+func splitCollection(value, collectionFormat string) []string {
+	sep := ","
+
+	switch collectionFormat {
+	case "ssv":
+		sep = " "
+	case "tsv":
+		sep = "\t"
+	case "pipes":
+		sep = "|"
+	}
+
+	return strings.Split(value, sep)
+}
+
+func decodeScalar(typ, value string) (interface{}, error) {
+	switch typ {
+	case "integer":
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer value %q", value)
+		}
+
+		return v, nil
+	case "number":
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number value %q", value)
+		}
+
+		return v, nil
+	case "boolean":
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean value %q", value)
+		}
+
+		return v, nil
+	}
+
+	return value, nil
+}
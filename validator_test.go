@@ -0,0 +1,143 @@
+package swgen_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/assertjson"
+	"github.com/swaggest/swgen"
+)
+
+func validatedGenerator() *swgen.Generator {
+	type (
+		getReq struct {
+			ID    int    `path:"id" minimum:"1"`
+			Limit int    `query:"limit" maximum:"100"`
+			IDs   []int  `query:"ids" collectionFormat:"pipes"`
+			Token string `header:"X-Token" required:"true" minLength:"3"`
+			Sort  string `query:"sort" enum:"asc,desc"`
+		}
+
+		createReq struct {
+			Name  string `json:"name" required:"true" minLength:"2"`
+			Count int    `json:"count" minimum:"0"`
+		}
+
+		item struct {
+			ID int `json:"id"`
+		}
+	)
+
+	g := swgen.NewGenerator()
+	g.SetBasePath("/v1")
+
+	g.SetPathItem(swgen.PathItemInfo{Method: http.MethodGet, Path: "/items/{id}", Request: getReq{}, Response: item{}})
+	g.SetPathItem(swgen.PathItemInfo{Method: http.MethodGet, Path: "/items/latest", Response: item{}})
+	g.SetPathItem(swgen.PathItemInfo{Method: http.MethodPost, Path: "/items", Request: createReq{}, Response: item{}})
+
+	return g
+}
+
+func TestValidateRequests(t *testing.T) {
+	called := 0
+	h := swgen.ValidateRequests(validatedGenerator(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called++
+
+		if r.Body != nil {
+			b, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			_, _ = w.Write(b)
+		}
+	}))
+
+	do := func(method, uri, body string, header map[string]string) *httptest.ResponseRecorder {
+		var req *http.Request
+		if body != "" {
+			req = httptest.NewRequest(method, uri, strings.NewReader(body))
+		} else {
+			req = httptest.NewRequest(method, uri, nil)
+		}
+
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+
+		rw := httptest.NewRecorder()
+		h.ServeHTTP(rw, req)
+
+		return rw
+	}
+
+	rw := do(http.MethodGet, "/v1/items/0?limit=101&ids=1|x&sort=up", "", nil)
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+	assert.Equal(t, "application/json", rw.Header().Get("Content-Type"))
+	assertjson.Equal(t, []byte(`{
+	  "error":"invalid request",
+	  "errors":[
+		{"in":"path","name":"id","message":"must be >= 1 but found 0"},
+		{"in":"query","name":"limit","message":"must be <= 100 but found 101"},
+		{"in":"query","name":"ids","message":"invalid integer value \"x\""},
+		{"in":"header","name":"X-Token","message":"missing value"},
+		{"in":"query","name":"sort","message":"value must be one of \"asc\", \"desc\""}
+	  ]
+	}`), rw.Body.Bytes(), rw.Body.String())
+	assert.Equal(t, 0, called)
+
+	rw = do(http.MethodGet, "/v1/items/1?ids=1|2&sort=asc", "", map[string]string{"X-Token": "abc"})
+	assert.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+	assert.Equal(t, 1, called)
+
+	// Static path is preferred over templated one, so "latest" is not validated as integer id.
+	rw = do(http.MethodGet, "/v1/items/latest", "", nil)
+	assert.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+	assert.Equal(t, 2, called)
+
+	rw = do(http.MethodPost, "/v1/items", `{"name":"a","count":-1}`, nil)
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+	assertjson.Equal(t, []byte(`{
+	  "error":"invalid request",
+	  "errors":[
+		{"in":"body","name":"/count","message":"must be >= 0 but found -1"},
+		{"in":"body","name":"/name","message":"length must be >= 2, but got 1"}
+	  ]
+	}`), rw.Body.Bytes(), rw.Body.String())
+
+	rw = do(http.MethodPost, "/v1/items", "", nil)
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+	assertjson.Equal(t, []byte(`{"error":"invalid request","errors":[{"in":"body","message":"missing request body"}]}`),
+		rw.Body.Bytes(), rw.Body.String())
+
+	// Body is available to handler after validation.
+	rw = do(http.MethodPost, "/v1/items", `{"name":"abc"}`, nil)
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, `{"name":"abc"}`, rw.Body.String())
+
+	// Unknown operations are passed through.
+	rw = do(http.MethodDelete, "/v1/items/1", "", nil)
+	assert.Equal(t, http.StatusOK, rw.Code)
+
+	rw = do(http.MethodGet, "/other", "", nil)
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, 5, called)
+}
+
+func TestRequestValidator_ValidateRequest(t *testing.T) {
+	v := swgen.NewRequestValidator(validatedGenerator())
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/items/5", nil)
+	req.Header.Set("X-Token", "ab")
+
+	err := v.ValidateRequest(req)
+	require.Error(t, err)
+	assert.Equal(t, "invalid request: header X-Token: length must be >= 3, but got 2", err.Error())
+
+	op, path, params := v.FindOperation(req)
+	require.NotNil(t, op)
+	assert.Equal(t, "/items/{id}", path)
+	assert.Equal(t, map[string]string{"id": "5"}, params)
+}
@@ -0,0 +1,116 @@
+package swgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const mimeYAML = "application/yaml"
+
+// GenDocumentYAML returns document specification in YAML.
+//
+// Document is rendered to JSON first, so vendor extensions are kept and keys follow JSON order.
+func (g *Generator) GenDocumentYAML() ([]byte, error) {
+	return g.genDocumentYAML(nil)
+}
+
+func (g *Generator) genDocumentYAML(host *string) ([]byte, error) {
+	data, err := g.genDocument(host)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonToYAML(data)
+}
+
+// acceptsYAML checks if request asks for YAML document.
+func acceptsYAML(r *http.Request) bool {
+	if strings.HasSuffix(r.URL.Path, ".yaml") || strings.HasSuffix(r.URL.Path, ".yml") {
+		return true
+	}
+
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(accept, ";", 2)[0])
+		if mediaType == mimeYAML || mediaType == "application/x-yaml" || mediaType == "text/yaml" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// jsonToYAML converts JSON document to YAML preserving order of object keys.
+func jsonToYAML(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	v, err := decodeOrderedJSON(dec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	return yaml.Marshal(v)
+}
+
+// decodeOrderedJSON reads next JSON value with objects as yaml.MapSlice.
+func decodeOrderedJSON(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			obj := yaml.MapSlice{}
+
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+
+				value, err := decodeOrderedJSON(dec)
+				if err != nil {
+					return nil, err
+				}
+
+				obj = append(obj, yaml.MapItem{Key: key, Value: value})
+			}
+
+			_, err = dec.Token() // Closing delimiter.
+
+			return obj, err
+		case '[':
+			arr := []interface{}{}
+
+			for dec.More() {
+				value, err := decodeOrderedJSON(dec)
+				if err != nil {
+					return nil, err
+				}
+
+				arr = append(arr, value)
+			}
+
+			_, err = dec.Token() // Closing delimiter.
+
+			return arr, err
+		}
+	case json.Number:
+		if i, err := strconv.ParseInt(t.String(), 10, 64); err == nil {
+			return i, nil
+		}
+
+		return t.Float64()
+	}
+
+	return tok, nil
+}
@@ -0,0 +1,105 @@
+package swgen_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/swgen"
+)
+
+func yamlGenerator() *swgen.Generator {
+	type item struct {
+		ID    int     `json:"id" minimum:"1" maximum:"1e10"`
+		Price float64 `json:"price" multipleOf:"0.01"`
+	}
+
+	g := swgen.NewGenerator()
+	g.SetInfo("Title", "", "", "1.0")
+	g.AddExtendedField("x-audience", "internal")
+
+	info := swgen.PathItemInfo{Method: http.MethodGet, Path: "/items", Response: new(item)}
+	info.AddExtendedField("x-internal", true)
+	g.SetPathItem(info)
+
+	return g
+}
+
+const expectedYAML = `swagger: "2.0"
+info:
+  title: Title
+  description: ""
+  termsOfService: ""
+  contact:
+    name: ""
+  license:
+    name: ""
+  version: "1.0"
+basePath: /
+schemes:
+- http
+- https
+paths:
+  /items:
+    get:
+      summary: ""
+      description: ""
+      responses:
+        "200":
+          description: OK
+          schema:
+            $ref: '#/definitions/item'
+      x-internal: true
+definitions:
+  item:
+    type: object
+    properties:
+      id:
+        type: integer
+        format: int32
+        maximum: 10000000000
+        minimum: 1
+      price:
+        type: number
+        format: double
+        multipleOf: 0.01
+x-audience: internal
+`
+
+func TestGenerator_GenDocumentYAML(t *testing.T) {
+	g := yamlGenerator()
+
+	for i := 0; i < 5; i++ {
+		b, err := g.GenDocumentYAML()
+		require.NoError(t, err)
+		assert.Equal(t, expectedYAML, string(b))
+	}
+}
+
+func TestGenerator_ServeHTTP_yaml(t *testing.T) {
+	g := yamlGenerator()
+
+	req := httptest.NewRequest(http.MethodGet, "/docs/swagger.yaml", nil)
+	rw := httptest.NewRecorder()
+	g.ServeHTTP(rw, req)
+
+	assert.Equal(t, "application/yaml", rw.Header().Get("Content-Type"))
+	assert.Equal(t, expectedYAML, rw.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/docs", nil)
+	req.Header.Set("Accept", "text/html, application/yaml;q=0.9")
+	rw = httptest.NewRecorder()
+	g.ServeHTTP(rw, req)
+
+	assert.Equal(t, "application/yaml", rw.Header().Get("Content-Type"))
+	assert.Equal(t, expectedYAML, rw.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/docs/swagger.json", nil)
+	rw = httptest.NewRecorder()
+	g.ServeHTTP(rw, req)
+
+	assert.Equal(t, "application/json", rw.Header().Get("Content-Type"))
+	assert.Contains(t, rw.Body.String(), `"x-audience":"internal"`)
+}